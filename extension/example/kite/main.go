@@ -0,0 +1,57 @@
+// Kite is an example plugin: kites that sail across the top of the board
+// from wave 2, worth 3 points each. Build it and load it with
+//
+//	go build -buildmode=plugin -o kite.so ./extension/example/kite
+//	go build -tags plugins . && ./gobowarrow --plugins kite.so
+package main
+
+import "github.com/ashX04/gobowarrow/extension"
+
+// Kite tuning
+const (
+	kiteSpeed  = 6.0 // cells per second
+	kitePoints = 3
+)
+
+var kiteArt = []string{"<>", "\\/", " ~"}
+
+// Kinds is what the game loads from the plugin
+func Kinds() []extension.Kind {
+	return []extension.Kind{{
+		Name:      "kite",
+		FirstWave: 2,
+		Chance:    0.02,
+		New:       newKite,
+	}}
+}
+
+// kite drifts across from the left edge on the wind
+type kite struct {
+	x, y float64
+}
+
+func newKite(w extension.World) extension.Entity {
+	return &kite{x: -2, y: float64(1 + w.Random(max(w.Height()/3, 1)))}
+}
+
+func (k *kite) Update(w extension.World, dt float64) bool {
+	k.x += (kiteSpeed + w.Wind()) * dt
+	return k.x < float64(w.Width())
+}
+
+func (k *kite) Render(c extension.Canvas) {
+	for i, line := range kiteArt {
+		c.Text(int(k.x), int(k.y)+i, line, "208")
+	}
+}
+
+// Collide pops the kite when an arrow's tip reaches its diamond
+func (k *kite) Collide(x, y int) (extension.Hit, bool) {
+	kx, ky := int(k.x), int(k.y)
+	if y < ky || y > ky+1 || x < kx-1 || x > kx+1 {
+		return extension.Hit{}, false
+	}
+	return extension.Hit{Points: kitePoints, Stop: true, Remove: true}, true
+}
+
+func main() {}
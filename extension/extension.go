@@ -0,0 +1,66 @@
+// Package extension is what a plugin builds against to add its own things
+// to the board, such as new balloons or obstacles. The game runs them
+// alongside its own: it moves, draws and checks arrows against each one
+// every tick.
+//
+// A plugin is a Go plugin (go build -buildmode=plugin) whose main package
+// exports a function named by Symbol, with the signature of Kinds. It has
+// to be built with the same Go version and the same version of this module
+// as the game loading it, which has to be built with -tags plugins.
+package extension
+
+// Symbol is the name of the function a plugin exports
+const Symbol = "Kinds"
+
+// Kinds is the signature of the function a plugin exports: everything it
+// adds to the game
+type Kinds = func() []Kind
+
+// Kind is one type of thing a plugin adds, and how often the waves bring
+// one on
+type Kind struct {
+	Name      string
+	FirstWave int     // earliest wave it comes on in
+	Chance    float64 // chance of one coming on each tick from then on
+	New       func(w World) Entity
+}
+
+// Entity is one thing on the board. Its cells are the board's: columns
+// from the left and rows from the top.
+type Entity interface {
+	// Update moves the entity on by dt seconds of play. It returns false
+	// once the entity is finished with and should be taken off the board.
+	Update(w World, dt float64) bool
+
+	// Render draws the entity
+	Render(c Canvas)
+
+	// Collide says what happens to an arrow whose tip is at cell (x, y).
+	// ok is false when the arrow misses it.
+	Collide(x, y int) (h Hit, ok bool)
+}
+
+// Hit is what an arrow running into an entity does
+type Hit struct {
+	Points int  // scored the way a balloon's points are
+	Stop   bool // the arrow ends there
+	Remove bool // the entity is taken off the board
+}
+
+// World is the run an entity is part of
+type World interface {
+	Width() int
+	Height() int
+	Wind() float64 // sideways drift in cells per second, negative blows left
+
+	// Random returns a number in [0, n) from the run's RNG. Entities should
+	// draw their randomness from it so seeded runs replay the same.
+	Random(n int) int
+}
+
+// Canvas is what an entity draws on
+type Canvas interface {
+	// Text writes text starting at cell (x, y) in color, a 256-color code
+	// or #rrggbb. An empty color uses the theme's text color.
+	Text(x, y int, text, color string)
+}
@@ -0,0 +1,210 @@
+// Package achievements tracks unlockable goals across runs.
+package achievements
+
+import (
+	"encoding/json"
+	"errors"
+	"maps"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// EventKind identifies something that happened in a game
+type EventKind int
+
+const (
+	BalloonPopped EventKind = iota
+	WaveCleared
+)
+
+// Event is fed to the engine by the game loop
+type Event struct {
+	Kind    EventKind
+	Seconds float64 // game time when the event happened
+	Combo   int     // combo after a pop
+	Escaped int     // balloons that escaped during a cleared wave
+}
+
+// Achievement is a goal with a predicate over the engine's progress
+type Achievement struct {
+	ID          string
+	Name        string
+	Description string
+	check       func(p *Progress) bool
+}
+
+// Progress is what predicates look at when an event arrives
+type Progress struct {
+	Last       Event
+	TotalPops  int       // lifetime pops, persisted
+	RecentPops []float64 // game times of pops inside the burst window
+}
+
+// burstWindow and burstPops define the "frenzy" achievement
+const (
+	burstWindow = 10.0
+	burstPops   = 5
+)
+
+// All lists every achievement in display order
+var All = []Achievement{
+	{
+		ID:          "first-pop",
+		Name:        "First Blood",
+		Description: "Pop your first balloon",
+		check:       func(p *Progress) bool { return p.TotalPops >= 1 },
+	},
+	{
+		ID:          "centurion",
+		Name:        "Centurion",
+		Description: "Pop 100 balloons",
+		check:       func(p *Progress) bool { return p.TotalPops >= 100 },
+	},
+	{
+		ID:          "frenzy",
+		Name:        "Frenzy",
+		Description: "Pop 5 balloons in 10 seconds",
+		check:       func(p *Progress) bool { return len(p.RecentPops) >= burstPops },
+	},
+	{
+		ID:          "combo-10",
+		Name:        "Sharpshooter",
+		Description: "Reach a 10 hit combo",
+		check:       func(p *Progress) bool { return p.Last.Kind == BalloonPopped && p.Last.Combo >= 10 },
+	},
+	{
+		ID:          "perfect-wave",
+		Name:        "Perfect Wave",
+		Description: "Clear a wave without letting a balloon escape",
+		check:       func(p *Progress) bool { return p.Last.Kind == WaveCleared && p.Last.Escaped == 0 },
+	},
+}
+
+// state is the persisted part of the engine
+type state struct {
+	Unlocked  map[string]time.Time `json:"unlocked"`
+	TotalPops int                  `json:"total_pops"`
+}
+
+// Engine evaluates achievements as events arrive
+type Engine struct {
+	path     string
+	progress Progress
+	unlocked map[string]time.Time
+}
+
+// DefaultPath returns the achievements file under the user config dir
+func DefaultPath() (string, error) {
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "bowarrow", "achievements.json"), nil
+}
+
+// New returns an engine with nothing unlocked that never saves
+func New() *Engine {
+	return &Engine{unlocked: make(map[string]time.Time)}
+}
+
+// Load reads unlock state from path. A missing file starts fresh.
+func Load(path string) (*Engine, error) {
+	e := New()
+	e.path = path
+
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return e, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var s state
+	if err := json.Unmarshal(data, &s); err != nil {
+		return nil, err
+	}
+	if s.Unlocked != nil {
+		e.unlocked = s.Unlocked
+	}
+	e.progress.TotalPops = s.TotalPops
+	return e, nil
+}
+
+// Snapshot is the unlock state of an engine at one moment. It can be
+// saved on another goroutine while the engine carries on recording.
+type Snapshot struct {
+	path  string
+	state state
+}
+
+// Snapshot copies the engine's unlock state for saving
+func (e *Engine) Snapshot() Snapshot {
+	return Snapshot{
+		path: e.path,
+		state: state{
+			Unlocked:  maps.Clone(e.unlocked),
+			TotalPops: e.progress.TotalPops,
+		},
+	}
+}
+
+// Save writes the unlock state to disk; engines made with New are skipped
+func (s Snapshot) Save() error {
+	if s.path == "" {
+		return nil
+	}
+	if err := os.MkdirAll(filepath.Dir(s.path), 0o755); err != nil {
+		return err
+	}
+	data, err := json.MarshalIndent(s.state, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(s.path, data, 0o644)
+}
+
+// StartRun clears per-run progress such as the pop burst window
+func (e *Engine) StartRun() {
+	e.progress.RecentPops = nil
+	e.progress.Last = Event{}
+}
+
+// Record updates progress and returns any achievements it unlocked
+func (e *Engine) Record(ev Event) []Achievement {
+	p := &e.progress
+	p.Last = ev
+
+	if ev.Kind == BalloonPopped {
+		p.TotalPops++
+		p.RecentPops = append(p.RecentPops, ev.Seconds)
+		// Drop pops that fell out of the burst window
+		for len(p.RecentPops) > 0 && ev.Seconds-p.RecentPops[0] > burstWindow {
+			p.RecentPops = p.RecentPops[1:]
+		}
+	}
+
+	var unlocked []Achievement
+	for _, a := range All {
+		if _, done := e.unlocked[a.ID]; done {
+			continue
+		}
+		if a.check(p) {
+			e.unlocked[a.ID] = time.Now()
+			unlocked = append(unlocked, a)
+		}
+	}
+	return unlocked
+}
+
+// Unlocked reports whether the achievement with id has been earned
+func (e *Engine) Unlocked(id string) bool {
+	_, ok := e.unlocked[id]
+	return ok
+}
+
+// Count returns how many achievements have been earned
+func (e *Engine) Count() int {
+	return len(e.unlocked)
+}
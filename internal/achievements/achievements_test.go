@@ -0,0 +1,38 @@
+package achievements
+
+import (
+	"path/filepath"
+	"testing"
+)
+
+func TestSnapshotIsUnchangedByLaterEvents(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "achievements.json")
+	e, err := Load(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	e.Record(Event{Kind: BalloonPopped, Seconds: 1})
+	snapshot := e.Snapshot()
+
+	// Recording while the snapshot is written is what the game does, so
+	// run them side by side for the race detector
+	done := make(chan error)
+	go func() { done <- snapshot.Save() }()
+	for i := range 200 {
+		e.Record(Event{Kind: BalloonPopped, Seconds: float64(2 + i)})
+	}
+	if err := <-done; err != nil {
+		t.Fatal(err)
+	}
+
+	saved, err := Load(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := saved.progress.TotalPops; got != 1 {
+		t.Errorf("saved TotalPops = %d, want 1", got)
+	}
+	if !saved.Unlocked("first-pop") || saved.Unlocked("centurion") {
+		t.Errorf("saved unlocks = %v, want first-pop alone", saved.unlocked)
+	}
+}
@@ -0,0 +1,123 @@
+// Package anim plays timed sequences of sprite frames. An Animation is an
+// immutable description shared by everything that shows it; each sprite
+// on screen keeps its own Player to track where it is in the sequence.
+package anim
+
+// Mode says what happens after the last frame
+type Mode int
+
+const (
+	// Loop starts over from the first frame
+	Loop Mode = iota
+	// Once holds the last frame and reports Done
+	Once
+)
+
+// Frame is one step of an animation
+type Frame struct {
+	Art    []string // sprite lines; nil keeps the sprite's own art
+	DX, DY int      // offset from the sprite's position, in cells
+	Ticks  int      // how long the frame shows; at least one tick
+}
+
+// Animation is a sequence of frames
+type Animation struct {
+	Frames []Frame
+	Mode   Mode
+}
+
+// Duration is the total length of one pass through the frames in ticks
+func (a *Animation) Duration() int {
+	total := 0
+	for _, f := range a.Frames {
+		total += max(f.Ticks, 1)
+	}
+	return total
+}
+
+// Player tracks playback of an Animation. The zero Player shows an empty
+// frame and is already done.
+type Player struct {
+	anim    *Animation
+	frame   int
+	elapsed int // ticks spent on the current frame
+	done    bool
+}
+
+// Play starts a at its first frame
+func Play(a *Animation) Player {
+	return Player{anim: a, done: len(a.Frames) == 0}
+}
+
+// Update advances playback by one tick
+func (p *Player) Update() {
+	if p.anim == nil || p.done {
+		return
+	}
+	p.elapsed++
+	if p.elapsed < max(p.anim.Frames[p.frame].Ticks, 1) {
+		return
+	}
+	p.elapsed = 0
+	if p.frame+1 < len(p.anim.Frames) {
+		p.frame++
+		return
+	}
+	switch p.anim.Mode {
+	case Loop:
+		p.frame = 0
+	case Once:
+		p.done = true
+	}
+}
+
+// Skip advances playback by n ticks, e.g. to desynchronise sprites that
+// share a looping animation
+func (p *Player) Skip(n int) {
+	for range n {
+		p.Update()
+	}
+}
+
+// Position is how far a Player is through its animation. It's kept apart
+// from the Animation, which is shared, so playback can be saved and picked
+// up again with Resume.
+type Position struct {
+	Frame   int  `json:"frame"`
+	Elapsed int  `json:"elapsed"`
+	Done    bool `json:"done"`
+}
+
+// Position returns how far playback has got
+func (p Player) Position() Position {
+	return Position{Frame: p.frame, Elapsed: p.elapsed, Done: p.done}
+}
+
+// Resume plays a from pos, as saved from a Player of the same animation.
+// A position past a's end starts it over.
+func Resume(a *Animation, pos Position) Player {
+	p := Play(a)
+	if pos.Frame < 0 || pos.Frame >= len(a.Frames) || pos.Elapsed < 0 {
+		return p
+	}
+	p.frame, p.elapsed, p.done = pos.Frame, pos.Elapsed, pos.Done
+	return p
+}
+
+// Frame is the frame currently showing
+func (p Player) Frame() Frame {
+	if p.anim == nil || len(p.anim.Frames) == 0 {
+		return Frame{}
+	}
+	return p.anim.Frames[p.frame]
+}
+
+// Done reports whether a Once animation has finished
+func (p Player) Done() bool {
+	return p.anim == nil || p.done
+}
+
+// Is reports whether the player is playing a
+func (p Player) Is(a *Animation) bool {
+	return p.anim == a
+}
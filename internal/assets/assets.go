@@ -0,0 +1,219 @@
+// Package assets holds the game's built-in art: balloon sprites, the boss,
+// power-up balloons and the frame sequences for the bow and explosions.
+package assets
+
+import (
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/ashX04/gobowarrow/internal/anim"
+)
+
+// Sprite is a named piece of balloon art with its default color
+type Sprite struct {
+	Name   string
+	Art    []string
+	Color  lipgloss.Color
+	Marker string // shown in the middle in accessible mode, so color isn't needed to tell sprites apart
+	Letter string // the marker in plain ASCII, for monochrome terminals
+}
+
+// Balloons are the built-in balloons; levels refer to them by name
+var Balloons = []Sprite{
+	{
+		Name: "round",
+		Art: []string{
+			"  .-^^-.",
+			" /      \\",
+			"|        |",
+			" \\      /",
+			"  `----´",
+			"    ||   ",
+		},
+		Color:  "213", // Pink
+		Marker: "▲",
+		Letter: "A",
+	},
+	{
+		Name: "oval",
+		Art: []string{
+			"  .===.",
+			" (     )",
+			"|       |",
+			" (     )",
+			"  `---´",
+			"   ||  ",
+		},
+		Color:  "204", // Red
+		Marker: "■",
+		Letter: "#",
+	},
+	{
+		Name: "ring",
+		Art: []string{
+			"  _____",
+			" /     \\",
+			"|   ○   |",
+			" \\     /",
+			"  ‾‾‾‾‾",
+			"   ||   ",
+		},
+		Color:  "39", // Blue
+		Marker: "○",
+		Letter: "o",
+	},
+	{
+		Name: "dot",
+		Art: []string{
+			"  .===.",
+			" /     \\",
+			"|   •   |",
+			" \\     /",
+			"  `---´",
+			"   ||   ",
+		},
+		Color:  "48", // Green
+		Marker: "●",
+		Letter: "*",
+	},
+}
+
+// Find looks up a built-in sprite by name
+func Find(name string) (Sprite, bool) {
+	for _, s := range Balloons {
+		if s.Name == name {
+			return s, true
+		}
+	}
+	return Sprite{}, false
+}
+
+// SmallBalloon and LargeBalloon replace a sprite's art for the off sizes
+var (
+	SmallBalloon = []string{
+		" .-. ",
+		"(   )",
+		" `-´",
+		"  |",
+	}
+
+	LargeBalloon = []string{
+		"   .-~~~~-.   ",
+		"  /        \\",
+		" |          |",
+		"|            |",
+		" |          |",
+		"  \\        /",
+		"   `-.__.-´",
+		"     ||",
+	}
+)
+
+// Golden is the rare golden balloon
+var Golden = []string{
+	"  .-*-.",
+	" / ✦   \\",
+	"|   $   |",
+	" \\   ✦ /",
+	"  `-*-´",
+	"   ||   ",
+}
+
+// Shimmer cycles across a golden balloon to make it glint
+var Shimmer = []lipgloss.Color{"220", "226", "229", "214"}
+
+// PowerUp is drawn for every power-up balloon; its color tells them apart
+var PowerUp = []string{
+	"  .-*-.",
+	" /  ★  \\",
+	"|  ★ ★  |",
+	" \\     /",
+	"  `-*-´",
+	"   ||   ",
+}
+
+// Boss is the giant balloon that turns up every few waves
+var Boss = []string{
+	"    .-~~~~~~-.    ",
+	"  .'  ◣    ◢  '.  ",
+	" /   (●)  (●)   \\ ",
+	"|       __       |",
+	"|     \\____/     |",
+	" \\              / ",
+	"  '.          .'  ",
+	"    `-.____.-´    ",
+	"       ||||       ",
+}
+
+// Minion is the little balloon a boss releases
+var Minion = []string{
+	" .-. ",
+	"( ‿ )",
+	" `-´ ",
+	"  |  ",
+}
+
+// The archer is three rows tall: head, bow and legs. Arrows leave from
+// the bow row, the middle one.
+var (
+	// BowIdle is the archer at rest
+	BowIdle = &anim.Animation{
+		Frames: []anim.Frame{{Art: []string{"o ", "|)", "/\\"}}},
+		Mode:   anim.Loop,
+	}
+
+	// BowDraw nocks an arrow and pulls the string back while a shot charges
+	BowDraw = &anim.Animation{
+		Frames: []anim.Frame{
+			{Art: []string{"o ", "|)", "/\\"}, Ticks: 2},
+			{Art: []string{"o ", "|}", "/\\"}, Ticks: 2},
+			{Art: []string{"o ", "|>", "/\\"}, Ticks: 1},
+		},
+		Mode: anim.Once,
+	}
+
+	// BowRelease follows through after a shot
+	BowRelease = &anim.Animation{
+		Frames: []anim.Frame{
+			{Art: []string{"o/", "( ", "/\\"}, Ticks: 1},
+			{Art: []string{"o ", "|)", "/\\"}, Ticks: 2},
+		},
+		Mode: anim.Once,
+	}
+
+	// The archer leans the way it's moving
+	LeanUp = &anim.Animation{
+		Frames: []anim.Frame{{Art: []string{"o ", "|)", "/ "}, Ticks: 3}},
+		Mode:   anim.Once,
+	}
+	LeanDown = &anim.Animation{
+		Frames: []anim.Frame{{Art: []string{"o ", "|)", " \\"}, Ticks: 3}},
+		Mode:   anim.Once,
+	}
+	LeanBack = &anim.Animation{
+		Frames: []anim.Frame{{Art: []string{"o ", "\\)", "/\\"}, Ticks: 3}},
+		Mode:   anim.Once,
+	}
+	LeanForward = &anim.Animation{
+		Frames: []anim.Frame{{Art: []string{" o", "/)", "/\\"}, Ticks: 3}},
+		Mode:   anim.Once,
+	}
+
+	// BirdFlap beats a bird's wings as it flies
+	BirdFlap = &anim.Animation{
+		Frames: []anim.Frame{
+			{Art: []string{"\\v/"}, Ticks: 2},
+			{Art: []string{"-v-"}, Ticks: 2},
+		},
+		Mode: anim.Loop,
+	}
+
+	// Explosion flashes where a balloon popped
+	Explosion = &anim.Animation{
+		Frames: []anim.Frame{
+			{Art: []string{"  \\|/  ", " --*-- ", "  /|\\  "}, Ticks: 2},
+			{Art: []string{" \\ | / ", "-- * --", " / | \\ "}, Ticks: 2},
+			{Art: []string{" .   . ", "   ·   ", " '   ' "}, Ticks: 1},
+		},
+		Mode: anim.Once,
+	}
+)
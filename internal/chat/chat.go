@@ -0,0 +1,103 @@
+// Package chat reads a Twitch channel's chat over IRC so viewers can take
+// part in a stream. It only listens: it logs in anonymously and never
+// posts.
+package chat
+
+import (
+	"bufio"
+	"crypto/tls"
+	"fmt"
+	"math/rand"
+	"net"
+	"strings"
+	"time"
+)
+
+// DefaultServer is Twitch's IRC endpoint
+const DefaultServer = "irc.chat.twitch.tv:6697"
+
+// dialTimeout bounds how long connecting to the server may take
+const dialTimeout = 10 * time.Second
+
+// Message is one chat line
+type Message struct {
+	User string
+	Text string
+}
+
+// Client is a read-only connection to one channel
+type Client struct {
+	conn net.Conn
+	r    *bufio.Reader
+}
+
+// Dial joins channel on server. Servers on the IRC TLS port 6697 are
+// reached over TLS.
+func Dial(server, channel string) (*Client, error) {
+	dialer := &net.Dialer{Timeout: dialTimeout}
+	var conn net.Conn
+	var err error
+	if strings.HasSuffix(server, ":6697") {
+		conn, err = tls.DialWithDialer(dialer, "tcp", server, nil)
+	} else {
+		conn, err = dialer.Dial("tcp", server)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	// Twitch lets anyone read chat as justinfan followed by digits
+	nick := fmt.Sprintf("justinfan%d", 10000+rand.Intn(90000))
+	channel = "#" + strings.ToLower(strings.TrimPrefix(channel, "#"))
+	if _, err := fmt.Fprintf(conn, "NICK %s\r\nJOIN %s\r\n", nick, channel); err != nil {
+		conn.Close()
+		return nil, err
+	}
+	return &Client{conn: conn, r: bufio.NewReader(conn)}, nil
+}
+
+// Next blocks until the next chat message, answering server pings while
+// it waits
+func (c *Client) Next() (Message, error) {
+	for {
+		line, err := c.r.ReadString('\n')
+		if err != nil {
+			return Message{}, err
+		}
+		prefix, command, params, trailing := parse(strings.TrimRight(line, "\r\n"))
+		switch command {
+		case "PING":
+			if _, err := fmt.Fprintf(c.conn, "PONG :%s\r\n", trailing); err != nil {
+				return Message{}, err
+			}
+		case "PRIVMSG":
+			if len(params) == 0 {
+				continue
+			}
+			user, _, _ := strings.Cut(prefix, "!")
+			return Message{User: user, Text: trailing}, nil
+		}
+	}
+}
+
+// Close leaves the chat
+func (c *Client) Close() error {
+	return c.conn.Close()
+}
+
+// parse splits an IRC line into its prefix, command, middle parameters and
+// trailing parameter. Message tags are dropped.
+func parse(line string) (prefix, command string, params []string, trailing string) {
+	if strings.HasPrefix(line, "@") {
+		_, line, _ = strings.Cut(line, " ")
+	}
+	if strings.HasPrefix(line, ":") {
+		prefix, line, _ = strings.Cut(line[1:], " ")
+	}
+	line, trailing, _ = strings.Cut(line, " :")
+	fields := strings.Fields(line)
+	if len(fields) == 0 {
+		return prefix, "", nil, trailing
+	}
+	return prefix, fields[0], fields[1:], trailing
+}
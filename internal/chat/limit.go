@@ -0,0 +1,54 @@
+package chat
+
+import "time"
+
+// maxSeen is how many viewers are remembered before expired ones are dropped
+const maxSeen = 1000
+
+// Limiter keeps a busy chat from flooding the board. It lets through at
+// most a set number of messages a minute, and one per viewer per cooldown.
+type Limiter struct {
+	perMinute int
+	cooldown  time.Duration
+	tokens    float64
+	last      time.Time            // when tokens were last topped up
+	seen      map[string]time.Time // when each viewer last got through
+}
+
+// NewLimiter allows perMinute messages a minute, each viewer waiting
+// cooldown between theirs
+func NewLimiter(perMinute int, cooldown time.Duration) *Limiter {
+	return &Limiter{
+		perMinute: perMinute,
+		cooldown:  cooldown,
+		tokens:    float64(perMinute),
+		seen:      make(map[string]time.Time),
+	}
+}
+
+// Allow reports whether user's message at now gets through
+func (l *Limiter) Allow(user string, now time.Time) bool {
+	if !l.last.IsZero() {
+		refill := now.Sub(l.last).Minutes() * float64(l.perMinute)
+		l.tokens = min(l.tokens+refill, float64(l.perMinute))
+	}
+	l.last = now
+
+	// Forget viewers whose cooldown is over once the list gets long
+	if len(l.seen) > maxSeen {
+		for u, at := range l.seen {
+			if now.Sub(at) >= l.cooldown {
+				delete(l.seen, u)
+			}
+		}
+	}
+	if at, ok := l.seen[user]; ok && now.Sub(at) < l.cooldown {
+		return false
+	}
+	if l.tokens < 1 {
+		return false
+	}
+	l.tokens--
+	l.seen[user] = now
+	return true
+}
@@ -0,0 +1,443 @@
+// Package config loads game tuning from the user's config file.
+package config
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/BurntSushi/toml"
+
+	"github.com/ashX04/gobowarrow/internal/chat"
+	"github.com/ashX04/gobowarrow/internal/difficulty"
+	"github.com/ashX04/gobowarrow/internal/physics"
+	"github.com/ashX04/gobowarrow/internal/profile"
+	"github.com/ashX04/gobowarrow/internal/sound"
+	"github.com/ashX04/gobowarrow/internal/theme"
+)
+
+// Config holds the tunable game parameters
+type Config struct {
+	TickRate    int     `toml:"tick_rate"`    // simulation ticks per second
+	FrameRate   int     `toml:"frame_rate"`   // screen redraws per second
+	SpawnChance float64 `toml:"spawn_chance"` // chance of a new balloon each tick
+	MaxArrows   int     `toml:"max_arrows"`   // arrows allowed in flight at once
+	QuiverSize  int     `toml:"quiver_size"`  // arrows fired before a reload
+	ArrowSpeed  int     `toml:"arrow_speed"`  // cells an arrow moves per second
+	Gravity     float64 `toml:"gravity"`      // arrow drop in rows per second squared
+	Width       int     `toml:"width"`        // board width including padding
+	Height      int     `toml:"height"`       // board height in rows
+	BoardScale  int     `toml:"board_scale"`  // percent of the terminal the board fills, overriding width and height; 0 keeps them
+	Difficulty  string  `toml:"difficulty"`   // a difficulty preset name
+	Seed        int64   `toml:"seed"`         // RNG seed; 0 picks one at random
+	Renderer    string  `toml:"renderer"`     // how arrows and trails are drawn
+	MoveRepeat  float64 `toml:"move_repeat"`  // rows per second the archer glides while a key is held
+	Theme       string  `toml:"theme"`        // color scheme name
+	Background  string  `toml:"background"`   // terminal background the colors are picked for
+	Accessible  bool    `toml:"accessible"`   // mark balloon types with symbols as well as color
+	Monochrome  bool    `toml:"monochrome"`   // draw without color; on by itself when NO_COLOR is set
+	ASCII       bool    `toml:"ascii"`        // draw with plain ASCII for fonts missing the game's symbols
+	SpritePack  string  `toml:"sprite_pack"`  // folder under the sprites dir whose balloons replace the built-in ones
+	ZenStats    bool    `toml:"zen_stats"`    // show hits and accuracy in Zen mode, which hides them otherwise
+	BowSkin     string  `toml:"bow_skin"`     // unlocked bow skin to draw the archer with; empty for the plain one
+	BalloonPack string  `toml:"balloon_pack"` // unlocked balloon colors to use; empty for the theme's
+
+	ReducedMotion bool   `toml:"reduced_motion"` // no screen shake, hit-stop, flashing or flicker, for players sensitive to them
+	Scenery       bool   `toml:"scenery"`        // draw drifting clouds and hills behind the balloons
+	DayLength     int    `toml:"day_length"`     // seconds of play from one dawn to the next; 0 keeps it midday
+	Weather       string `toml:"weather"`        // clear, changing, rain or fog
+
+	Sound  string   `toml:"sound"`   // how sound effects are played: off, bell or audio
+	BellOn []string `toml:"bell_on"` // effects that ring the bell when sound is "bell"
+
+	MusicVolume int    `toml:"music_volume"` // 0 to 100; 0 turns the music off
+	MusicFile   string `toml:"music_file"`   // 16-bit PCM WAV to loop instead of the built-in tune
+
+	LeaderboardURL string `toml:"leaderboard_url"` // HTTPS leaderboard server; empty keeps scores offline
+	PlayerName     string `toml:"player_name"`     // name shown on the online leaderboard
+
+	BotReaction float64 `toml:"bot_reaction"`  // seconds the computer archer waits between shots
+	BotAimError float64 `toml:"bot_aim_error"` // rows the computer archer may misjudge a balloon by
+
+	ChatChannel  string `toml:"chat_channel"`  // Twitch channel whose viewers spawn balloons; empty disables
+	ChatServer   string `toml:"chat_server"`   // IRC server the channel is on
+	ChatRate     int    `toml:"chat_rate"`     // most viewer balloons per minute
+	ChatCooldown int    `toml:"chat_cooldown"` // seconds each viewer waits between balloons
+
+	Keys   Keys                   `toml:"keys"`
+	HUD    HUD                    `toml:"hud"`
+	Themes map[string]theme.Theme `toml:"themes"` // custom color schemes by name
+}
+
+// Keys binds each play control to the keys that trigger it. Names are as
+// Bubble Tea reports them ("up", "w", "ctrl+s"), plus "space".
+type Keys struct {
+	Up      []string `toml:"up"`
+	Down    []string `toml:"down"`
+	Left    []string `toml:"left"`
+	Right   []string `toml:"right"`
+	AimUp   []string `toml:"aim_up"`
+	AimDown []string `toml:"aim_down"`
+	Shoot   []string `toml:"shoot"`
+	Reload  []string `toml:"reload"`
+	Pause   []string `toml:"pause"`
+	Quit    []string `toml:"quit"`
+
+	// Panning only does anything on levels wider than the board
+	PanLeft  []string `toml:"pan_left"`
+	PanRight []string `toml:"pan_right"`
+}
+
+// DefaultKeys are the controls the game ships with
+func DefaultKeys() Keys {
+	return Keys{
+		Up:      []string{"up"},
+		Down:    []string{"down"},
+		Left:    []string{"left"},
+		Right:   []string{"right"},
+		AimUp:   []string{"w"},
+		AimDown: []string{"s"},
+		Shoot:   []string{"space"},
+		Reload:  []string{"r"},
+		Pause:   []string{"p"},
+		Quit:    []string{"q"},
+
+		PanLeft:  []string{"["},
+		PanRight: []string{"]"},
+	}
+}
+
+// Binding is one control and the keys bound to it
+type Binding struct {
+	Name string // the control's name under [keys]
+	Keys *[]string
+}
+
+// Bindings lists every control in k, in the order they're shown
+func (k *Keys) Bindings() []Binding {
+	return []Binding{
+		{"up", &k.Up}, {"down", &k.Down}, {"left", &k.Left}, {"right", &k.Right},
+		{"aim_up", &k.AimUp}, {"aim_down", &k.AimDown},
+		{"shoot", &k.Shoot}, {"reload", &k.Reload}, {"pause", &k.Pause}, {"quit", &k.Quit},
+		{"pan_left", &k.PanLeft}, {"pan_right", &k.PanRight},
+	}
+}
+
+// HUD places each panel of the heads-up display around the board
+type HUD struct {
+	Quiver string `toml:"quiver"` // arrows left, arrow kinds and active power-ups
+	Timer  string `toml:"timer"`  // the countdown in timed modes
+	Score  string `toml:"score"`  // score, wave, lives and the rest of the status line
+	Combo  string `toml:"combo"`  // the current combo and its multiplier
+	Feed   string `toml:"feed"`   // recent events
+}
+
+// Panel places
+const (
+	PanelTop    = "top"    // above the board
+	PanelBottom = "bottom" // below the board
+	PanelLeft   = "left"   // beside the board on the left
+	PanelRight  = "right"  // beside the board on the right
+	PanelHidden = "hidden" // not shown
+)
+
+// PanelPlaces lists the panel places Validate accepts
+var PanelPlaces = []string{PanelTop, PanelBottom, PanelLeft, PanelRight, PanelHidden}
+
+// DefaultHUD puts every panel below the board but the event feed, which
+// goes on the right
+func DefaultHUD() HUD {
+	return HUD{
+		Quiver: PanelBottom,
+		Timer:  PanelBottom,
+		Score:  PanelBottom,
+		Combo:  PanelBottom,
+		Feed:   PanelRight,
+	}
+}
+
+// Panel is one HUD panel and where it goes
+type Panel struct {
+	Name  string // the panel's name under [hud]
+	Place *string
+}
+
+// Panels lists every panel in h, in the order they stack when they share
+// a place
+func (h *HUD) Panels() []Panel {
+	return []Panel{
+		{"quiver", &h.Quiver}, {"timer", &h.Timer}, {"score", &h.Score},
+		{"combo", &h.Combo}, {"feed", &h.Feed},
+	}
+}
+
+// MaxPlayerName is the longest player_name accepted
+const MaxPlayerName = 16
+
+// Renderer names
+const (
+	RendererCell    = "cell"    // one glyph per terminal cell
+	RendererBraille = "braille" // 2x4 braille dots per cell
+)
+
+// Renderers lists the renderer names Validate accepts
+var Renderers = []string{RendererCell, RendererBraille}
+
+// Sound settings
+const (
+	SoundOff   = "off"   // silent
+	SoundBell  = "bell"  // ring the terminal bell
+	SoundAudio = "audio" // play samples through the audio device
+)
+
+// Sounds lists the sound settings Validate accepts
+var Sounds = []string{SoundOff, SoundBell, SoundAudio}
+
+// Weather settings
+const (
+	WeatherClear    = "clear"    // never any weather
+	WeatherChanging = "changing" // showers and fog banks come and go
+	WeatherRain     = "rain"     // rain all the time; only for show
+	WeatherFog      = "fog"      // fog all the time, hiding distant balloons
+)
+
+// Weathers lists the weather settings Validate accepts
+var Weathers = []string{WeatherClear, WeatherChanging, WeatherRain, WeatherFog}
+
+// Background settings
+const (
+	BackgroundAuto  = "auto"  // ask the terminal
+	BackgroundDark  = "dark"  // colors for a dark background
+	BackgroundLight = "light" // colors for a light background
+)
+
+// Backgrounds lists the background settings Validate accepts
+var Backgrounds = []string{BackgroundAuto, BackgroundDark, BackgroundLight}
+
+// Default returns the built-in settings used when no file is present
+func Default() Config {
+	return Config{
+		TickRate:    10,
+		FrameRate:   30,
+		SpawnChance: 0.1,
+		MaxArrows:   3,
+		QuiverSize:  12,
+		ArrowSpeed:  20,
+		Gravity:     physics.DefaultGravity,
+		Width:       80,
+		Height:      20,
+		Difficulty:  difficulty.Default,
+		Renderer:    RendererCell,
+		Theme:       theme.Default,
+		Background:  BackgroundAuto,
+		MoveRepeat:  25,
+		Scenery:     true,
+		DayLength:   480,
+		Weather:     WeatherChanging,
+		Sound:       SoundOff,
+		BellOn:      []string{"pop", "game_over"},
+		BotReaction: 0.6,
+		BotAimError: 1.5,
+
+		ChatServer:   chat.DefaultServer,
+		ChatRate:     12,
+		ChatCooldown: 30,
+
+		Keys: DefaultKeys(),
+		HUD:  DefaultHUD(),
+	}
+}
+
+// DefaultPath returns the config file location under the user config dir
+func DefaultPath() (string, error) {
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "bowarrow", "config.toml"), nil
+}
+
+// Load reads the config at path on top of the defaults.
+// A missing file is not an error.
+func Load(path string) (Config, error) {
+	cfg := Default()
+
+	_, err := toml.DecodeFile(path, &cfg)
+	if errors.Is(err, os.ErrNotExist) {
+		return cfg, nil
+	}
+	if err != nil {
+		return Default(), err
+	}
+
+	if err := cfg.Validate(); err != nil {
+		return Default(), fmt.Errorf("%s: %w", path, err)
+	}
+	return cfg, nil
+}
+
+// Validate rejects values the game can't run with
+func (c Config) Validate() error {
+	switch {
+	case c.TickRate < 1 || c.TickRate > 120:
+		return fmt.Errorf("tick_rate must be between 1 and 120, got %d", c.TickRate)
+	case c.FrameRate < 1 || c.FrameRate > 240:
+		return fmt.Errorf("frame_rate must be between 1 and 240, got %d", c.FrameRate)
+	case c.SpawnChance < 0 || c.SpawnChance > 1:
+		return fmt.Errorf("spawn_chance must be between 0 and 1, got %g", c.SpawnChance)
+	case c.MaxArrows < 1:
+		return fmt.Errorf("max_arrows must be at least 1, got %d", c.MaxArrows)
+	case c.QuiverSize < 1:
+		return fmt.Errorf("quiver_size must be at least 1, got %d", c.QuiverSize)
+	case c.ArrowSpeed < 1:
+		return fmt.Errorf("arrow_speed must be at least 1, got %d", c.ArrowSpeed)
+	case c.Gravity < 0 || c.Gravity > 100:
+		return fmt.Errorf("gravity must be between 0 and 100, got %g", c.Gravity)
+	case c.Width < 40:
+		return fmt.Errorf("width must be at least 40, got %d", c.Width)
+	case c.Height < 10:
+		return fmt.Errorf("height must be at least 10, got %d", c.Height)
+	case c.BoardScale != 0 && (c.BoardScale < 30 || c.BoardScale > 100):
+		return fmt.Errorf("board_scale must be 0 or between 30 and 100, got %d", c.BoardScale)
+	case c.MoveRepeat < 1 || c.MoveRepeat > 200:
+		return fmt.Errorf("move_repeat must be between 1 and 200, got %g", c.MoveRepeat)
+	case c.DayLength != 0 && (c.DayLength < 60 || c.DayLength > 7200):
+		return fmt.Errorf("day_length must be 0 or between 60 and 7200, got %d", c.DayLength)
+	case c.MusicVolume < 0 || c.MusicVolume > 100:
+		return fmt.Errorf("music_volume must be between 0 and 100, got %d", c.MusicVolume)
+	case c.BotReaction < 0 || c.BotReaction > 5:
+		return fmt.Errorf("bot_reaction must be between 0 and 5, got %g", c.BotReaction)
+	case c.BotAimError < 0 || c.BotAimError > 10:
+		return fmt.Errorf("bot_aim_error must be between 0 and 10, got %g", c.BotAimError)
+	case c.ChatRate < 1 || c.ChatRate > 600:
+		return fmt.Errorf("chat_rate must be between 1 and 600, got %d", c.ChatRate)
+	case c.ChatCooldown < 0 || c.ChatCooldown > 3600:
+		return fmt.Errorf("chat_cooldown must be between 0 and 3600, got %d", c.ChatCooldown)
+	case c.ChatChannel != "" && c.ChatServer == "":
+		return errors.New("chat_server must be set to use chat_channel")
+	}
+	if _, ok := difficulty.Lookup(c.Difficulty); !ok {
+		return fmt.Errorf("difficulty must be one of %s, got %q",
+			strings.Join(difficulty.Names(), ", "), c.Difficulty)
+	}
+	if !slices.Contains(Renderers, c.Renderer) {
+		return fmt.Errorf("renderer must be one of %s, got %q",
+			strings.Join(Renderers, ", "), c.Renderer)
+	}
+	if !slices.Contains(Backgrounds, c.Background) {
+		return fmt.Errorf("background must be one of %s, got %q",
+			strings.Join(Backgrounds, ", "), c.Background)
+	}
+	if !slices.Contains(Sounds, c.Sound) {
+		return fmt.Errorf("sound must be one of %s, got %q",
+			strings.Join(Sounds, ", "), c.Sound)
+	}
+	if !slices.Contains(Weathers, c.Weather) {
+		return fmt.Errorf("weather must be one of %s, got %q",
+			strings.Join(Weathers, ", "), c.Weather)
+	}
+	for _, name := range c.BellOn {
+		if _, ok := sound.Lookup(name); !ok {
+			return fmt.Errorf("bell_on entries must be among %s, got %q",
+				strings.Join(sound.Names(), ", "), name)
+		}
+	}
+	for name, t := range c.Themes {
+		if err := t.Validate(); err != nil {
+			return fmt.Errorf("themes.%s: %w", name, err)
+		}
+	}
+	if _, ok := theme.Lookup(c.Theme, c.Themes); !ok {
+		return fmt.Errorf("theme must be one of %s, got %q",
+			strings.Join(theme.Names(c.Themes), ", "), c.Theme)
+	}
+	if c.SpritePack != "" && (c.SpritePack != filepath.Base(c.SpritePack) || strings.HasPrefix(c.SpritePack, ".")) {
+		return fmt.Errorf("sprite_pack must be a folder name in the sprites dir, got %q", c.SpritePack)
+	}
+	if _, ok := profile.Lookup(profile.BowSkin, c.BowSkin); c.BowSkin != "" && !ok {
+		return fmt.Errorf("bow_skin must be empty or one of %s, got %q",
+			strings.Join(profile.Names(profile.BowSkin), ", "), c.BowSkin)
+	}
+	if _, ok := profile.Lookup(profile.BalloonPack, c.BalloonPack); c.BalloonPack != "" && !ok {
+		return fmt.Errorf("balloon_pack must be empty or one of %s, got %q",
+			strings.Join(profile.Names(profile.BalloonPack), ", "), c.BalloonPack)
+	}
+	if c.LeaderboardURL != "" {
+		u, err := url.Parse(c.LeaderboardURL)
+		if err != nil || u.Scheme != "https" || u.Host == "" {
+			return fmt.Errorf("leaderboard_url must be an https:// URL, got %q", c.LeaderboardURL)
+		}
+	}
+	if len([]rune(c.PlayerName)) > MaxPlayerName {
+		return fmt.Errorf("player_name must be at most %d characters, got %q", MaxPlayerName, c.PlayerName)
+	}
+	if err := c.HUD.validate(); err != nil {
+		return err
+	}
+	return c.Keys.validate()
+}
+
+// validate makes sure every panel has a place
+func (h HUD) validate() error {
+	for _, p := range h.Panels() {
+		if !slices.Contains(PanelPlaces, *p.Place) {
+			return fmt.Errorf("hud.%s must be one of %s, got %q",
+				p.Name, strings.Join(PanelPlaces, ", "), *p.Place)
+		}
+	}
+	return nil
+}
+
+// ReservedKeys do the same thing whatever the game is doing, so no
+// control may be bound to them: quitting, suspending, the debug overlay,
+// hitboxes and the console
+var ReservedKeys = []string{"ctrl+c", "ctrl+z", "f3", "f4", "~", "`"}
+
+// validate makes sure every control has a key, none is reserved and no
+// key does two things
+func (k Keys) validate() error {
+	bound := map[string]string{}
+	for _, b := range k.Bindings() {
+		if len(*b.Keys) == 0 {
+			return fmt.Errorf("keys.%s needs at least one key", b.Name)
+		}
+		for _, key := range *b.Keys {
+			if slices.Contains(ReservedKeys, key) {
+				return fmt.Errorf("keys.%s can't use %q, which is reserved", b.Name, key)
+			}
+			if other, ok := bound[key]; ok {
+				return fmt.Errorf("key %q is bound to both keys.%s and keys.%s", key, other, b.Name)
+			}
+			bound[key] = b.Name
+		}
+	}
+	return nil
+}
+
+// Save writes the config to path, creating the parent directory if needed
+func (c Config) Save(path string) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+	var buf bytes.Buffer
+	if err := toml.NewEncoder(&buf).Encode(c); err != nil {
+		return err
+	}
+	return os.WriteFile(path, buf.Bytes(), 0o644)
+}
+
+// TickInterval is the time between simulation ticks
+func (c Config) TickInterval() time.Duration {
+	return time.Second / time.Duration(c.TickRate)
+}
+
+// FrameInterval is the time between screen redraws
+func (c Config) FrameInterval() time.Duration {
+	return time.Second / time.Duration(c.FrameRate)
+}
@@ -0,0 +1,16 @@
+package config
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestReservedKeysCantBeBound(t *testing.T) {
+	for _, key := range ReservedKeys {
+		c := Default()
+		c.Keys.Pause = []string{key}
+		if err := c.Validate(); err == nil || !strings.Contains(err.Error(), "reserved") {
+			t.Errorf("binding pause to %q: error = %v, want it reserved", key, err)
+		}
+	}
+}
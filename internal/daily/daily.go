@@ -0,0 +1,119 @@
+// Package daily derives the shared daily challenge and keeps a local
+// history of the player's attempts.
+package daily
+
+import (
+	"encoding/json"
+	"errors"
+	"hash/fnv"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+)
+
+// DateFormat is how days are written in the history file
+const DateFormat = "2006-01-02"
+
+// Today returns the current challenge day. Days roll over at midnight UTC
+// so players in every time zone share the same balloons.
+func Today() time.Time {
+	return time.Now().UTC().Truncate(24 * time.Hour)
+}
+
+// Seed derives the RNG seed for day; everyone gets the same one
+func Seed(day time.Time) int64 {
+	h := fnv.New64a()
+	h.Write([]byte("bowarrow daily " + day.Format(DateFormat)))
+	// Keep it positive and non-zero, since a zero seed means random
+	return int64(h.Sum64()>>1) | 1
+}
+
+// Result is one day's attempt
+type Result struct {
+	Date     string `json:"date"`
+	Score    int    `json:"score"`
+	Finished bool   `json:"finished"` // false if the run was abandoned
+}
+
+// History holds one result per day, newest first
+type History []Result
+
+// DefaultPath returns the history file location under the user config dir
+func DefaultPath() (string, error) {
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "bowarrow", "daily.json"), nil
+}
+
+// Load reads the history from disk. A missing file yields an empty history.
+func Load(path string) (History, error) {
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return History{}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var h History
+	if err := json.Unmarshal(data, &h); err != nil {
+		return nil, err
+	}
+	h.sort()
+	return h, nil
+}
+
+// Save writes the history to disk, creating the parent directory if needed
+func (h History) Save(path string) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+	data, err := json.MarshalIndent(h, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, 0o644)
+}
+
+// On returns the result recorded for day, if it was played
+func (h History) On(day time.Time) (Result, bool) {
+	date := day.Format(DateFormat)
+	for _, r := range h {
+		if r.Date == date {
+			return r, true
+		}
+	}
+	return Result{}, false
+}
+
+// Set returns a new history with r replacing any result on the same day
+func (h History) Set(r Result) History {
+	out := make(History, 0, len(h)+1)
+	for _, old := range h {
+		if old.Date != r.Date {
+			out = append(out, old)
+		}
+	}
+	out = append(out, r)
+	out.sort()
+	return out
+}
+
+// Best returns the highest finished score in the history
+func (h History) Best() int {
+	best := 0
+	for _, r := range h {
+		if r.Finished {
+			best = max(best, r.Score)
+		}
+	}
+	return best
+}
+
+// sort orders newest first; dates sort correctly as strings
+func (h History) sort() {
+	sort.Slice(h, func(i, j int) bool { return h[i].Date > h[j].Date })
+}
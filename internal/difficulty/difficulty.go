@@ -0,0 +1,66 @@
+// Package difficulty defines the selectable difficulty presets.
+package difficulty
+
+// Preset scales the base config for a difficulty level
+type Preset struct {
+	Name        string
+	SpawnScale  float64 // multiplier on the configured spawn chance
+	RiseSpeed   float64 // rows a balloon climbs per second
+	Wobble      float64 // max horizontal drift, in cells per second
+	ArrowsDelta int     // added to the configured max arrows in flight
+	QuiverDelta int     // added to the configured quiver size
+}
+
+var presets = []Preset{
+	{Name: "easy", SpawnScale: 0.6, RiseSpeed: 5, Wobble: 10, ArrowsDelta: 1, QuiverDelta: 4},
+	{Name: "normal", SpawnScale: 1, RiseSpeed: 10, Wobble: 10, ArrowsDelta: 0, QuiverDelta: 0},
+	{Name: "hard", SpawnScale: 1.5, RiseSpeed: 10, Wobble: 20, ArrowsDelta: -1, QuiverDelta: -2},
+	{Name: "insane", SpawnScale: 2.5, RiseSpeed: 20, Wobble: 30, ArrowsDelta: -1, QuiverDelta: -4},
+}
+
+// Default is the preset used when none is chosen
+const Default = "normal"
+
+// Lookup returns the preset with the given name
+func Lookup(name string) (Preset, bool) {
+	for _, p := range presets {
+		if p.Name == name {
+			return p, true
+		}
+	}
+	return Preset{}, false
+}
+
+// Names lists the presets from easiest to hardest
+func Names() []string {
+	names := make([]string, len(presets))
+	for i, p := range presets {
+		names[i] = p.Name
+	}
+	return names
+}
+
+// Next returns the preset after name, wrapping around to the easiest
+func Next(name string) Preset {
+	for i, p := range presets {
+		if p.Name == name {
+			return presets[(i+1)%len(presets)]
+		}
+	}
+	return presets[0]
+}
+
+// SpawnChance scales a base per-tick spawn chance, capped at 1
+func (p Preset) SpawnChance(base float64) float64 {
+	return min(base*p.SpawnScale, 1)
+}
+
+// MaxArrows adjusts a base arrow limit, never going below 1
+func (p Preset) MaxArrows(base int) int {
+	return max(base+p.ArrowsDelta, 1)
+}
+
+// QuiverSize adjusts a base quiver capacity, never going below 1
+func (p Preset) QuiverSize(base int) int {
+	return max(base+p.QuiverDelta, 1)
+}
@@ -0,0 +1,251 @@
+// Package entities holds the objects that move around the board: the
+// balloons the player pops, the arrows they shoot and the birds in the
+// way. They share their components, such as Position, and each reports
+// whether it's still Alive, so one cull clears them all off the board.
+package entities
+
+import (
+	"math"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/ashX04/gobowarrow/internal/anim"
+	"github.com/ashX04/gobowarrow/internal/physics"
+)
+
+// ArrowKind selects how an arrow behaves on impact
+type ArrowKind int
+
+// PowerUp identifies the buff a balloon grants when popped; zero is none
+type PowerUp int
+
+// Live returns the entities in list still on the board, in a new slice so
+// copies of the game holding the old one are left alone
+func Live[E interface{ Alive() bool }](list []E) []E {
+	live := make([]E, 0, len(list))
+	for _, e := range list {
+		if e.Alive() {
+			live = append(live, e)
+		}
+	}
+	return live
+}
+
+// Position is the component for an entity that moves a whole cell or less
+// at a time, drawn between where it was and where it is
+type Position struct {
+	X, Y  float64 // sub-cell position, rounded when drawn
+	PrevX float64 // position at the previous tick, for interpolation
+	PrevY float64
+}
+
+// At is a position with nothing to interpolate from, for an entity just
+// put on the board
+func At(x, y float64) Position {
+	return Position{X: x, Y: y, PrevX: x, PrevY: y}
+}
+
+// Settle keeps the current position as the previous tick's, before the
+// entity moves
+func (p *Position) Settle() {
+	p.PrevX, p.PrevY = p.X, p.Y
+}
+
+// Cell rounds the position to a board cell
+func (p Position) Cell() (int, int) {
+	return int(math.Round(p.X)), int(math.Round(p.Y))
+}
+
+// DrawnCell is the cell alpha of the way from the previous tick's
+// position to the current one
+func (p Position) DrawnCell(alpha float64) (int, int) {
+	v := lerp(physics.Vec{X: p.PrevX, Y: p.PrevY}, physics.Vec{X: p.X, Y: p.Y}, alpha)
+	return v.Cell()
+}
+
+// Balloon represents a target
+type Balloon struct {
+	Position
+	Popped  bool
+	Art     []string
+	Color   lipgloss.Color
+	Width   int
+	Height  int
+	PowerUp PowerUp     // buff granted when popped, if any
+	Points  int         // base score for popping it
+	Speed   float64     // rise rate relative to the wave's speed
+	Golden  bool        // rare bonus balloon that shimmers
+	Anim    anim.Player // idle bob, then the pop explosion
+	Despawn int         // ticks the explosion lingers once popped
+	Label   string      // viewer who sent it, shown beneath; empty for most balloons
+	Word    string      // shown above it: the word to type in typing mode, or a number in math mode
+	Marker  string      // symbol for its type, drawn in the middle in accessible mode
+	Cut     bool        // its string was shot through, so it's drifting off
+}
+
+// StringRows is how many rows at the bottom of the balloon's art are its
+// string: lines of nothing but "|". A balloon is never all string.
+func (b Balloon) StringRows() int {
+	rows := 0
+	for i := len(b.Art) - 1; i > 0; i-- {
+		line := strings.TrimSpace(b.Art[i])
+		if line == "" || strings.Trim(line, "|") != "" {
+			break
+		}
+		rows++
+	}
+	return rows
+}
+
+// BodyHeight is the balloon's height without its string
+func (b Balloon) BodyHeight() int {
+	return b.Height - b.StringRows()
+}
+
+// Center returns the middle of the balloon in board cells
+func (b Balloon) Center() (float64, float64) {
+	return b.X + float64(b.Width)/2, b.Y + float64(b.Height)/2
+}
+
+// Alive reports whether the balloon is still on the board. Popped balloons
+// stay until their explosion has played out.
+func (b Balloon) Alive() bool {
+	return !b.Popped || b.Despawn > 0
+}
+
+// Near reports whether balloons b and o are within gap cells of each other
+func (b Balloon) Near(o Balloon, gap float64) bool {
+	bw, bh := float64(b.Width), float64(b.Height)
+	ow, oh := float64(o.Width), float64(o.Height)
+	return b.X-gap <= o.X+ow && o.X-gap <= b.X+bw &&
+		b.Y-gap <= o.Y+oh && o.Y-gap <= b.Y+bh
+}
+
+// BirdWidth is how many columns a bird's art spans
+const BirdWidth = 3
+
+// Bird flies across the board, knocking aside any arrow it meets. Its
+// position is its left end.
+type Bird struct {
+	Position
+	VX, VY   float64     // cells per second; birds fly left, and climb once startled
+	Startled bool        // has knocked an arrow aside and is fleeing
+	Anim     anim.Player // wing beats
+}
+
+// Update moves the bird on by dt seconds
+func (b *Bird) Update(dt float64) {
+	b.Settle()
+	b.X += b.VX * dt
+	b.Y += b.VY * dt
+}
+
+// Alive reports whether the bird is still on the board. Birds leave off
+// the left edge, or off the top once startled.
+func (b Bird) Alive() bool {
+	return b.X > -BirdWidth && b.Y > -1
+}
+
+// Zone is the part of a target an arrow struck, from the rim in
+type Zone int
+
+const (
+	Missed   Zone = iota // nowhere on the target
+	Outer                // the outer ring
+	Inner                // the inner ring
+	Bullseye             // the middle
+)
+
+// TargetReach is how many rows a target's rings stand above and below its
+// bullseye: one for the inner ring and one more for the outer
+const TargetReach = 2
+
+// Target is a bullseye standing still on the practice range, side on to
+// the archer. Its rings are rows: the bullseye's, the inner ring's either
+// side of it, then the outer ring's.
+type Target struct {
+	X, Y  int   // column of its face, row of its bullseye
+	Stuck []int // rows arrows have stuck in it at
+}
+
+// Struck is the zone an arrow hits flying from one position to the next,
+// or Missed if it doesn't reach the target's face or passes above or below
+// it. The row is taken where the flight crosses the face, so a fast arrow
+// can't skip over it.
+func (t Target) Struck(from, to physics.Vec) (Zone, int) {
+	face := float64(t.X)
+	if from.X > face || to.X < face || to.X == from.X {
+		return Missed, 0
+	}
+	y := from.Y + (to.Y-from.Y)*(face-from.X)/(to.X-from.X)
+	row := int(math.Round(y))
+	return t.ZoneAt(row), row
+}
+
+// ZoneAt is the zone of the target's face on the given row
+func (t Target) ZoneAt(row int) Zone {
+	switch dy := row - t.Y; {
+	case dy == 0:
+		return Bullseye
+	case dy == 1 || dy == -1:
+		return Inner
+	case dy == TargetReach || dy == -TargetReach:
+		return Outer
+	}
+	return Missed
+}
+
+// Arrow represents the player's projectile
+type Arrow struct {
+	Body   physics.Body // float position, rounded to cells when drawn
+	Kind   ArrowKind
+	Active bool
+	Symbol string
+	Charge float64     // 0 for a tapped shot, up to 1 for a full charge
+	Pierce int         // extra balloons the arrow can pass through
+	Hit    bool        // popped at least one balloon
+	Pops   int         // balloons it has struck and popped
+	Guest  bool        // fired by the second archer in a networked match
+	Prev   physics.Vec // position at the previous tick, for interpolation
+	Origin physics.Vec // where it left the bow, for the long shot bonus
+}
+
+// Update advances the arrow by dt seconds under gravity
+func (a *Arrow) Update(gravity, dt float64) {
+	a.Prev = a.Body.Pos
+	a.Body.Step(gravity, dt)
+}
+
+// Cell returns the board cell the arrow occupies
+func (a Arrow) Cell() (int, int) {
+	return a.Body.Pos.Cell()
+}
+
+// DrawnCell is the arrow's cell as it appears alpha of the way from its
+// previous tick to its current one
+func (a Arrow) DrawnCell(alpha float64) (int, int) {
+	return a.Drawn(alpha).Cell()
+}
+
+// Alive reports whether the arrow is still in flight
+func (a Arrow) Alive() bool {
+	return a.Active
+}
+
+// Speed returns the arrow's current speed in cells per second
+func (a Arrow) Speed() float64 {
+	return math.Hypot(a.Body.Vel.X, a.Body.Vel.Y)
+}
+
+// Drawn returns a copy of the arrow placed alpha of the way from its
+// previous tick to its current one
+func (a Arrow) Drawn(alpha float64) Arrow {
+	a.Body.Pos = lerp(a.Prev, a.Body.Pos, alpha)
+	return a
+}
+
+// lerp blends from a to b by t
+func lerp(a, b physics.Vec, t float64) physics.Vec {
+	return a.Add(b.Add(a.Scale(-1)).Scale(t))
+}
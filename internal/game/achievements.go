@@ -0,0 +1,50 @@
+package game
+
+import (
+	"fmt"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/ashX04/gobowarrow/internal/achievements"
+)
+
+// recordEvent feeds the achievements engine and queues toasts for unlocks
+func (m *Game) recordEvent(ev achievements.Event) {
+	if m.achievements == nil {
+		return
+	}
+	ev.Seconds = float64(m.timer) / float64(m.cfg.TickRate)
+	for _, a := range m.achievements.Record(ev) {
+		m.notify("🏆 "+a.Name+": "+a.Description, m.theme.Accent)
+	}
+}
+
+// recordPop checks a balloon the host popped against the achievements
+func (m *Game) recordPop(e event) {
+	if !e.guest {
+		m.recordEvent(achievements.Event{Kind: achievements.BalloonPopped, Combo: m.combo})
+	}
+}
+
+// recordWave checks a cleared wave against the achievements
+func (m *Game) recordWave(e event) {
+	m.recordEvent(achievements.Event{Kind: achievements.WaveCleared, Escaped: e.escaped})
+}
+
+type achievementsSavedMsg struct{ err error }
+
+// saveAchievements persists unlock state off the Update goroutine. The
+// state is copied here, on it, since Update goes on recording into the
+// engine while the copy is written.
+func saveAchievements(engine *achievements.Engine) tea.Cmd {
+	if engine == nil {
+		return nil
+	}
+	snapshot := engine.Snapshot()
+	return func() tea.Msg {
+		if err := snapshot.Save(); err != nil {
+			return achievementsSavedMsg{err: fmt.Errorf("achievements: %w", err)}
+		}
+		return achievementsSavedMsg{}
+	}
+}
@@ -0,0 +1,55 @@
+package game
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/ashX04/gobowarrow/internal/physics"
+	"github.com/ashX04/gobowarrow/internal/render"
+)
+
+// maxAim is how many aimStep notches the bow tilts either side of level
+const maxAim = 6
+
+// aimStep is the launch slope, in rows per column, of one aim notch
+const aimStep = 0.125
+
+// cellAspect is how much taller a terminal cell is than it is wide
+const cellAspect = 2
+
+// previewSteps is how many ticks ahead the trajectory preview reaches
+const previewSteps = 12
+
+// adjustAim tilts the bow; negative aim points upward
+func (m *Game) adjustAim(step int) {
+	m.aim = min(max(m.aim+step, -maxAim), maxAim)
+}
+
+// aimSlope is the launch slope for the current aim
+func (m Game) aimSlope() float64 {
+	return float64(m.aim) * aimStep
+}
+
+// aimLabel describes the on-screen launch angle for the HUD
+func (m Game) aimLabel() string {
+	degrees := math.Atan(-m.aimSlope()*cellAspect) * 180 / math.Pi
+	// Rounded as an int, since a level bow would print as -0
+	return fmt.Sprintf("%+d°", int(math.Round(degrees)))
+}
+
+// drawTrajectory dots the arc a tapped arrow would follow from the bow
+func (m Game) drawTrajectory(f *render.FrameBuffer) {
+	dotStyle := render.Style{FG: m.theme.Faint, Faint: true}
+
+	preview := m.newArrow(0, m.aimSlope())
+	points := make([]physics.Vec, 0, previewSteps)
+	for range previewSteps {
+		preview.Update(m.cfg.Gravity, m.dt())
+		x, y := preview.Cell()
+		if y >= f.Height() || x >= m.width {
+			break
+		}
+		points = append(points, preview.Body.Pos)
+	}
+	m.renderer.DrawPath(f, points, dotStyle)
+}
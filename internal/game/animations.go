@@ -0,0 +1,114 @@
+package game
+
+import (
+	"github.com/ashX04/gobowarrow/internal/anim"
+	"github.com/ashX04/gobowarrow/internal/assets"
+	"github.com/ashX04/gobowarrow/internal/entities"
+	"github.com/ashX04/gobowarrow/internal/render"
+)
+
+// despawnTicks is how long a popped balloon lingers to show its explosion;
+// it matches the length of assets.Explosion
+const despawnTicks = 5
+
+// balloonBob sways balloons up and down a row as they drift
+var balloonBob = &anim.Animation{
+	Frames: []anim.Frame{{Ticks: 5}, {DY: 1, Ticks: 5}},
+	Mode:   anim.Loop,
+}
+
+// tickAnimations advances every sprite animation by one tick
+func (m *Game) tickAnimations() {
+	// Draw the bow while the shot charges. A release or lean plays out
+	// before the archer rests again; so does nothing else.
+	switch {
+	case m.charging:
+		if !m.bow.Is(assets.BowDraw) {
+			m.bow = anim.Play(assets.BowDraw)
+		}
+	case m.bow.Is(assets.BowDraw) || m.bow.Done():
+		m.bow = anim.Play(assets.BowIdle)
+	}
+	m.bow.Update()
+
+	for i := range m.balloons {
+		b := &m.balloons[i]
+		b.Anim.Update()
+		if b.Popped && b.Despawn > 0 {
+			b.Despawn--
+		}
+	}
+}
+
+// lean tips the archer the way it's stepping, unless it's drawing the bow
+func (m *Game) lean(dir step) {
+	if m.charging {
+		return
+	}
+	switch dir {
+	case step{dy: -1}:
+		m.bow = anim.Play(assets.LeanUp)
+	case step{dy: 1}:
+		m.bow = anim.Play(assets.LeanDown)
+	case step{dx: -1}:
+		m.bow = anim.Play(assets.LeanBack)
+	case step{dx: 1}:
+		m.bow = anim.Play(assets.LeanForward)
+	}
+}
+
+// archerArt is the archer's sprite for the current frame. A sprite pack's
+// archer is a single glyph that doesn't animate.
+func (m Game) archerArt() []string {
+	if m.pack.Archer != "" {
+		return []string{m.pack.Archer}
+	}
+	if art := m.bow.Frame().Art; len(art) > 0 {
+		return art
+	}
+	return assets.BowIdle.Frames[0].Art
+}
+
+// drawArcher draws the archer's sprite with its middle row on the archer's
+// row, where arrows leave from. It goes down before the arrows so they
+// show over it. Each glyph is set on its own, so the sprite faces the
+// other way on a mirrored board and its blanks don't cover anything.
+func (m Game) drawArcher(f *render.FrameBuffer, style render.Style) {
+	art := m.archerArt()
+	top := m.archer - len(art)/2
+	for i, line := range art {
+		for _, g := range render.Glyphs(line) {
+			if g.Text != " " {
+				f.Set(m.archerCol+g.Col, top+i, g.Text, style)
+			}
+		}
+	}
+}
+
+// drawExplosion renders a popped balloon's explosion centered on where it
+// was. With reduced motion the last, calmest frame is shown throughout
+// rather than flashing through them all.
+func (m Game) drawExplosion(f *render.FrameBuffer, b entities.Balloon, dim bool) {
+	art := b.Anim.Frame().Art
+	if m.cfg.ReducedMotion {
+		frames := assets.Explosion.Frames
+		art = frames[len(frames)-1].Art
+	}
+	if b.Despawn == 0 || len(art) == 0 {
+		return
+	}
+	style := render.Style{FG: b.Color, Bold: true}
+	if dim {
+		style = m.dimStyle()
+	}
+	x, y := b.Cell()
+	x += (b.Width - render.ArtWidth(art)) / 2
+	y += (b.Height - len(art)) / 2
+	for i, line := range art {
+		for _, g := range render.Glyphs(line) {
+			if g.Text != " " {
+				f.Set(x+g.Col, y+i, g.Text, style)
+			}
+		}
+	}
+}
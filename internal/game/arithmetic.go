@@ -0,0 +1,166 @@
+package game
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/ashX04/gobowarrow/internal/render"
+)
+
+// Math mode tuning
+const (
+	mathSeconds = 120 // length of a Math round
+	mathPenalty = 2   // points lost for popping a wrong answer
+	mathSpread  = 5   // most a wrong answer is off by
+)
+
+// Operations math mode asks about, as shown in the sum
+const (
+	opAdd = iota
+	opSubtract
+	opMultiply
+	opCount
+)
+
+var opSigns = [opCount]string{"+", "−", "×"}
+
+// problem is the sum math mode is asking about
+type problem struct {
+	a, b, op int
+}
+
+func (p problem) String() string {
+	return fmt.Sprintf("%d %s %d", p.a, opSigns[p.op], p.b)
+}
+
+// answer is what p comes to
+func (p problem) answer() int {
+	switch p.op {
+	case opSubtract:
+		return p.a - p.b
+	case opMultiply:
+		return p.a * p.b
+	}
+	return p.a + p.b
+}
+
+// reportCard tallies a math round's answers by operation
+type reportCard struct {
+	right, wrong [opCount]int
+}
+
+// nextProblem sets a new sum, harder as the waves go on: adding within
+// ten, then taking away within twenty, then times tables
+func (m *Game) nextProblem() {
+	op := opAdd
+	switch n := m.wave.number; {
+	case n >= 5:
+		op = m.rng.Intn(opCount)
+	case n >= 3:
+		op = m.rng.Intn(opMultiply)
+	}
+	var p problem
+	switch op {
+	case opAdd:
+		p.a = m.rng.Intn(11)
+		p.b = m.rng.Intn(11 - p.a)
+	case opSubtract:
+		p.a = m.rng.Intn(21)
+		p.b = m.rng.Intn(p.a + 1)
+	case opMultiply:
+		p.a, p.b = 2+m.rng.Intn(9), 2+m.rng.Intn(9)
+	}
+	p.op = op
+	m.problem = p
+}
+
+// tickMath numbers new balloons. Whenever no balloon on the board has the
+// answer the next one does; the rest are near misses.
+func (m *Game) tickMath() {
+	if !m.currentMode().math {
+		return
+	}
+	answer := strconv.Itoa(m.problem.answer())
+	showing := m.wordTarget(answer, true) >= 0
+	for i := range m.balloons {
+		b := &m.balloons[i]
+		if b.Word != "" || b.Popped {
+			continue
+		}
+		if !showing {
+			b.Word, showing = answer, true
+			continue
+		}
+		off := 1 + m.rng.Intn(mathSpread)
+		if m.rng.Intn(2) == 0 && m.problem.answer() >= off {
+			off = -off
+		}
+		b.Word = strconv.Itoa(m.problem.answer() + off)
+	}
+}
+
+// checkAnswer marks a popped balloon carrying word. The answer moves on to
+// the next sum; anything else costs mathPenalty points and the combo.
+func (m *Game) checkAnswer(word string, x, y float64, guest bool) bool {
+	op := m.problem.op
+	if word == strconv.Itoa(m.problem.answer()) {
+		m.card.right[op]++
+		m.announce(fmt.Sprintf("✔ %s = %s", m.problem, word))
+		m.nextProblem()
+		return true
+	}
+	m.card.wrong[op]++
+	score, combo := m.tally(guest)
+	*score = max(*score-mathPenalty, 0)
+	*combo = 0
+	m.addPopup(x, y, fmt.Sprintf("-%d", mathPenalty), lipgloss.Color("196"))
+	m.announce(fmt.Sprintf("✘ %s isn't %s", m.problem, word))
+	return false
+}
+
+// drawProblem shows the sum across the top of the board
+func (m Game) drawProblem(f *render.FrameBuffer) {
+	if !m.currentMode().math {
+		return
+	}
+	text := fmt.Sprintf("  %s = ?  ", m.problem)
+	style := render.Style{FG: m.theme.Contrast, BG: m.theme.Accent, Bold: true}
+	f.Text(0, max((f.Width()-lipgloss.Width(text))/2, 0), text, style)
+}
+
+// reportCardView sums up a math round: how each kind of sum went and a
+// grade for the lot
+func (m Game) reportCardView() []string {
+	lines := []string{"Report card:"}
+	right, total := 0, 0
+	for op := range opCount {
+		n := m.card.right[op] + m.card.wrong[op]
+		if n == 0 {
+			continue
+		}
+		lines = append(lines, fmt.Sprintf("  %s  %d of %d right", opSigns[op], m.card.right[op], n))
+		right += m.card.right[op]
+		total += n
+	}
+	if total == 0 {
+		return append(lines, "  No sums answered")
+	}
+	return append(lines, "  Grade: "+grade(float64(right)/float64(total)))
+}
+
+// grade turns the fraction of answers right into a school grade
+func grade(score float64) string {
+	switch {
+	case score >= 0.9:
+		return "A ⭐"
+	case score >= 0.8:
+		return "B"
+	case score >= 0.7:
+		return "C"
+	case score >= 0.6:
+		return "D"
+	}
+	return "Keep practising!"
+}
@@ -0,0 +1,78 @@
+package game
+
+import (
+	"math"
+
+	"github.com/ashX04/gobowarrow/internal/anim"
+	"github.com/ashX04/gobowarrow/internal/assets"
+	"github.com/ashX04/gobowarrow/internal/entities"
+	"github.com/ashX04/gobowarrow/internal/render"
+)
+
+// Bird tuning
+const (
+	birdWave    = 3    // first wave birds fly in
+	birdChance  = 0.01 // chance of a bird each tick from then on
+	birdSpeed   = 8.0  // cells per second, give or take a quarter
+	birdFlee    = 6.0  // rows per second a startled bird climbs away
+	deflectSlow = 0.4  // fraction of its forward speed an arrow keeps after a bird
+	deflectDrop = 6.0  // rows per second of downward speed a deflected arrow picks up
+)
+
+// spawnBird sometimes sends a bird in from the right edge, somewhere in
+// the top half of the board, from birdWave on
+func (m *Game) spawnBird() {
+	if m.wave.number < birdWave || m.rng.Float64() >= birdChance {
+		return
+	}
+	x := float64(m.width)
+	y := float64(1 + m.rng.Intn(max(m.height/2-1, 1)))
+	m.birds = append(m.birds, entities.Bird{
+		Position: entities.At(x, y),
+		VX:       -birdSpeed * (0.75 + m.rng.Float64()/2),
+		Anim:     anim.Play(assets.BirdFlap),
+	})
+}
+
+// tickBirds flies the birds on and lets go of the ones that have left
+// the board
+func (m *Game) tickBirds(dt float64) {
+	for i := range m.birds {
+		m.birds[i].Update(dt)
+		m.birds[i].Anim.Update()
+	}
+	m.birds = entities.Live(m.birds)
+}
+
+// deflectOffBirds knocks arrow a aside if it has flown into a bird. The
+// arrow keeps a little of its speed and drops away, so it can still hit a
+// balloon below, and the bird flees without blocking anything else.
+func (m *Game) deflectOffBirds(a *entities.Arrow, ax, ay int) {
+	for i := range m.birds {
+		b := &m.birds[i]
+		bx, by := b.Cell()
+		if b.Startled || ay != by || ax+4 < bx || ax > bx+entities.BirdWidth {
+			continue
+		}
+		a.Body.Vel.X *= deflectSlow
+		a.Body.Vel.Y = math.Abs(a.Body.Vel.Y) + deflectDrop
+		b.Startled = true
+		b.VY = -birdFlee
+		return
+	}
+}
+
+// drawBirds draws the birds in front of the balloons they fly past
+func (m Game) drawBirds(f *render.FrameBuffer, dim bool) {
+	style := render.Style{FG: m.theme.Text, Bold: true}
+	if dim {
+		style = m.dimStyle()
+	}
+	alpha := m.alpha()
+	for _, b := range m.birds {
+		x, y := b.DrawnCell(alpha)
+		for _, line := range b.Anim.Frame().Art {
+			f.Text(y, x, line, style)
+		}
+	}
+}
@@ -0,0 +1,144 @@
+package game
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/ashX04/gobowarrow/internal/assets"
+	"github.com/ashX04/gobowarrow/internal/entities"
+	"github.com/ashX04/gobowarrow/internal/render"
+	"github.com/ashX04/gobowarrow/internal/sound"
+)
+
+// Boss tuning
+const (
+	bossEvery     = 3   // a boss appears at the start of every Nth wave
+	bossHP        = 10  // hits needed to defeat a boss
+	bossBonus     = 50  // points awarded on defeat, times the wave number
+	bossSpeed     = 10  // drift speed in cells per second
+	bossTurnRate  = 1.0 // average drift changes per second
+	minionSeconds = 4   // time between minion spawns
+)
+
+// boss is a giant balloon that soaks up hits and drifts around the board
+type boss struct {
+	x, y       float64
+	dx, dy     int // current drift direction on each axis
+	hp         int
+	flashTicks int // ticks left of the hit flash
+}
+
+func (b *boss) width() int  { return render.ArtWidth(assets.Boss) }
+func (b *boss) height() int { return len(assets.Boss) }
+
+// cell rounds the boss's top-left corner to a board cell
+func (b *boss) cell() (int, int) {
+	return int(math.Round(b.x)), int(math.Round(b.y))
+}
+
+// contains reports whether the cell (x, y) is inside the boss's hitbox
+func (b *boss) contains(x, y int) bool {
+	bx, by := b.cell()
+	return x+4 >= bx && x <= bx+b.width() && y >= by && y < by+b.height()
+}
+
+// spawnBoss places a fresh boss at the middle of the right half
+func (m *Game) spawnBoss() {
+	b := &boss{hp: bossHP, dx: -1}
+	b.x = float64(m.minBalloonX+m.width-b.width()) / 2
+	b.y = float64(max((m.height-b.height())/2, 0))
+	m.boss = b
+}
+
+// updateBoss drifts the boss unpredictably and spawns minions
+func (m *Game) updateBoss(dt float64) {
+	b := m.boss
+	if b == nil {
+		return
+	}
+	if b.flashTicks > 0 {
+		b.flashTicks--
+	}
+
+	if m.rng.Float64() < bossTurnRate*dt {
+		b.dx = m.rng.Intn(3) - 1
+		b.dy = m.rng.Intn(3) - 1
+	}
+	b.x += float64(b.dx) * bossSpeed * dt
+	b.y += float64(b.dy) * bossSpeed * dt
+
+	// Bounce off the edges of its half of the board
+	minX, maxX := float64(m.minBalloonX), float64(m.width-b.width())
+	minY, maxY := 0.0, float64(m.height-b.height())
+	if b.x < minX || b.x > maxX {
+		b.dx = -b.dx
+		b.x = min(max(b.x, minX), maxX)
+	}
+	if b.y < minY || b.y > maxY {
+		b.dy = -b.dy
+		b.y = min(max(b.y, minY), maxY)
+	}
+
+	// Release a minion from the boss's string
+	if m.timer%(minionSeconds*m.cfg.TickRate) == 0 {
+		minion := m.newBalloon(assets.Minion, "204")
+		bx, by := b.cell()
+		minion.Position = entities.At(float64(bx+b.width()/2), float64(min(by+b.height(), m.height-1)))
+		m.balloons = append(m.balloons, minion)
+	}
+}
+
+// hitBoss applies an arrow hit to the boss and awards the bonus on defeat
+func (m *Game) hitBoss(a *entities.Arrow) {
+	m.countHit(a)
+	a.Active = false
+
+	b := m.boss
+	b.hp--
+	m.playSound(sound.BossHit)
+	m.shakeScreen()
+	b.flashTicks = 2
+	if b.hp > 0 {
+		return
+	}
+
+	bonus := bossBonus * m.wave.number
+	score, _ := m.tally(a.Guest)
+	*score += bonus
+	m.boss = nil
+	m.notify(fmt.Sprintf("👑 Boss defeated! +%d", bonus), m.theme.Accent)
+	m.announce(fmt.Sprintf("👑 Boss defeated +%d", bonus))
+}
+
+// drawBoss renders the boss sprite, flashing white when just hit. With
+// reduced motion it doesn't flash; the health bar shows the hit.
+func (m Game) drawBoss(f *render.FrameBuffer, dim bool) {
+	b := m.boss
+	if b == nil {
+		return
+	}
+	style := render.Style{FG: m.theme.Danger, Bold: true}
+	if b.flashTicks > 0 && !m.cfg.ReducedMotion {
+		style.FG = lipgloss.Color("231") // White
+	}
+	if dim {
+		style = m.dimStyle()
+	}
+
+	x, y := b.cell()
+	for i, line := range assets.Boss {
+		f.Text(y+i, x, line, style)
+	}
+}
+
+// bossHPView renders the boss health bar shown above the board
+func (m Game) bossHPView() string {
+	if m.boss == nil {
+		return ""
+	}
+	style := lipgloss.NewStyle().Foreground(m.theme.Danger).Bold(true)
+	bar := m.meter(30, float64(m.boss.hp)/bossHP, m.theme.Danger, m.theme.Score)
+	return style.Render("BOSS ") + bar + style.Render(fmt.Sprintf(" %d/%d", m.boss.hp, bossHP))
+}
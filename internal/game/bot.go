@@ -0,0 +1,115 @@
+package game
+
+import (
+	"math"
+	"math/rand"
+	"time"
+
+	"github.com/ashX04/gobowarrow/internal/config"
+	"github.com/ashX04/gobowarrow/internal/physics"
+)
+
+// botFlight is the longest arrow flight, in seconds, the bot looks ahead
+const botFlight = 5.0
+
+// bot is the computer archer. It reads its own board the way a player
+// would and answers with the same key presses, so it can't do anything a
+// player couldn't.
+type bot struct {
+	reaction time.Duration // pause after each shot before lining up the next
+	aimError float64       // most rows a balloon's height is misjudged by
+	rng      *rand.Rand    // kept apart from the game's so the balloons still match
+	ready    time.Time     // when the bot may act again
+	misjudge float64       // this shot's error, in rows
+}
+
+// newBot returns a bot with the skill set in cfg
+func newBot(cfg config.Config, seed int64) *bot {
+	b := &bot{
+		reaction: time.Duration(cfg.BotReaction * float64(time.Second)),
+		aimError: cfg.BotAimError,
+		rng:      rand.New(rand.NewSource(seed)),
+	}
+	b.rollError()
+	return b
+}
+
+// rollError picks how far off the next shot is judged
+func (b *bot) rollError() {
+	b.misjudge = (2*b.rng.Float64() - 1) * b.aimError
+}
+
+// think picks the key to press this frame. It moves and aims one step at a
+// time, shoots once lined up, then waits out its reaction time.
+func (b *bot) think(m Game, now time.Time) (string, bool) {
+	if m.state != playing || now.Before(b.ready) {
+		return "", false
+	}
+	if m.arrowsLeft == 0 {
+		if m.reloadTicks == 0 {
+			return "r", true
+		}
+		return "", false
+	}
+
+	row, aim, ok := b.plan(m)
+	switch {
+	case !ok:
+		return "", false
+	case aim < m.aim:
+		return "w", true
+	case aim > m.aim:
+		return "s", true
+	case row != m.archer:
+		// Tap rather than hold, so the archer stops on the row it wants
+		b.ready = now.Add(repeatWindow)
+		if row < m.archer {
+			return "up", true
+		}
+		return "down", true
+	}
+	// Shots closer together than releaseGap would read as a held bow
+	b.ready = now.Add(max(b.reaction, releaseGap))
+	b.rollError()
+	return " ", true
+}
+
+// plan finds the row and aim that put an arrow through a balloon for the
+// fewest key presses. Each aim's flight is traced once and slid up or down
+// to meet every balloon it passes.
+func (b *bot) plan(m Game) (row, aim int, ok bool) {
+	best := -1
+	dt := m.dt()
+	rise := m.riseSpeed()
+	for a := -maxAim; a <= maxAim; a++ {
+		shot := physics.Launch(launchFrom(m.archerCol, 0), float64(m.cfg.ArrowSpeed), float64(a)*aimStep)
+		for t := dt; t < botFlight && shot.Pos.X < float64(m.width); t += dt {
+			shot.Step(m.cfg.Gravity, dt)
+			for _, balloon := range m.balloons {
+				if balloon.Popped || shot.Pos.X+4 < balloon.X || shot.Pos.X > balloon.X+float64(balloon.Width) {
+					continue
+				}
+				// Where the balloon will be once the arrow arrives
+				top := balloon.Y - rise*balloon.Speed*t + b.misjudge
+				lo := max(int(math.Ceil(top-shot.Pos.Y)), 0)
+				hi := min(int(math.Floor(top+float64(balloon.BodyHeight()-1)-shot.Pos.Y)), m.height-1)
+				if lo > hi {
+					continue
+				}
+				r := min(max(m.archer, lo), hi)
+				cost := abs(r-m.archer) + abs(a-m.aim)
+				if best < 0 || cost < best {
+					best, row, aim = cost, r, a
+				}
+			}
+		}
+	}
+	return row, aim, best >= 0
+}
+
+func abs(n int) int {
+	if n < 0 {
+		return -n
+	}
+	return n
+}
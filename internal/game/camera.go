@@ -0,0 +1,64 @@
+package game
+
+import "math"
+
+// Camera tuning
+const (
+	cameraEase  = 6.0 // how quickly the camera catches up, per second
+	cameraLead  = 3   // the followed arrow is kept this many quarters across the view
+	panFraction = 4   // a pan moves the view by this fraction of its width
+)
+
+// camera is which part of a world wider than the board is on screen. On
+// a board-sized world it never moves.
+type camera struct {
+	x, prevX float64 // the view's left column in the world, now and a tick ago
+	panned   bool    // the player moved it by hand; it stays put until the next shot
+}
+
+// viewWidth is how many of the world's columns fit on the board
+func (m Game) viewWidth() int {
+	return m.frame.Width()
+}
+
+// scrolls reports whether the world is wider than the board
+func (m Game) scrolls() bool {
+	return m.width > m.viewWidth()
+}
+
+// followCamera eases the camera toward the furthest arrow in flight, or
+// back to the archer once none are, unless the player has panned away
+func (m *Game) followCamera(dt float64) {
+	m.camera.prevX = m.camera.x
+	if !m.scrolls() || m.camera.panned {
+		return
+	}
+	target := 0.0
+	for _, a := range m.arrows {
+		if a.Active {
+			target = max(target, a.Body.Pos.X-float64(m.viewWidth()*cameraLead/4))
+		}
+	}
+	m.camera.x += (m.clampCamera(target) - m.camera.x) * min(cameraEase*dt, 1)
+}
+
+// pan moves the view a step left or right and holds it there
+func (m *Game) pan(dir int) {
+	if !m.scrolls() {
+		return
+	}
+	m.camera.x = m.clampCamera(m.camera.x + float64(dir*m.viewWidth()/panFraction))
+	m.camera.prevX = m.camera.x
+	m.camera.panned = true
+}
+
+// clampCamera keeps a view starting at x inside the world
+func (m Game) clampCamera(x float64) float64 {
+	return min(max(x, 0), float64(m.width-m.viewWidth()))
+}
+
+// cameraX is the view's left column for this frame, between the last two
+// ticks
+func (m Game) cameraX() int {
+	return int(math.Round(m.camera.prevX + (m.camera.x-m.camera.prevX)*m.alpha()))
+}
@@ -0,0 +1,91 @@
+package game
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/ashX04/gobowarrow/internal/render"
+)
+
+// Chain reaction tuning
+const (
+	chainGap       = 2 // cells between balloons for a pop to spread
+	chainBonus     = 2 // extra points per chained balloon
+	shockwaveTicks = 4 // how long a shockwave ring expands
+)
+
+// shockwave is an expanding ring drawn where a chain reaction started
+type shockwave struct {
+	x, y  float64
+	ticks int // ticks since it started
+}
+
+// chainReaction spreads pops from the given balloons to their neighbours,
+// cascading until no unpopped balloon is close enough to one that popped.
+// Everything it pops is credited to the archer who started it.
+func (m *Game) chainReaction(popped []int, guest bool) {
+	chained := 0
+	queue := popped
+	for len(queue) > 0 {
+		j := queue[0]
+		queue = queue[1:]
+		for k := range m.balloons {
+			if m.balloons[k].Popped || !m.balloons[j].Near(m.balloons[k], chainGap) {
+				continue
+			}
+			m.popBalloon(k, nil, 0, guest)
+			chained++
+			queue = append(queue, k)
+		}
+	}
+	if chained == 0 {
+		return
+	}
+
+	x, y := m.balloons[popped[0]].Center()
+	m.shockwaves = append(m.shockwaves, shockwave{x: x, y: y})
+	if m.currentMode().zen {
+		return
+	}
+	bonus := chainBonus * chained
+	score, _ := m.tally(guest)
+	*score += bonus
+	m.addPopup(x, y-1, fmt.Sprintf("chain x%d +%d", chained+1, bonus), "226")
+}
+
+// tickShockwaves grows every ring and drops the ones that have faded
+func (m *Game) tickShockwaves() {
+	live := m.shockwaves[:0]
+	for _, s := range m.shockwaves {
+		s.ticks++
+		if s.ticks < shockwaveTicks {
+			live = append(live, s)
+		}
+	}
+	m.shockwaves = live
+}
+
+// drawShockwaves rings each chain origin with a widening circle of sparks.
+// With reduced motion the chain's popup is left to show it instead.
+func (m Game) drawShockwaves(f *render.FrameBuffer) {
+	if m.cfg.ReducedMotion {
+		return
+	}
+	for _, s := range m.shockwaves {
+		spark, style := "∘", render.Style{FG: lipgloss.Color("226")}
+		if s.ticks >= shockwaveTicks/2 {
+			spark, style.Faint = "·", true
+		}
+		radius := float64(2 + 2*s.ticks)
+		for step := range 24 {
+			angle := float64(step) * 2 * math.Pi / 24
+			x := int(math.Round(s.x + radius*math.Cos(angle)))
+			y := int(math.Round(s.y + radius*math.Sin(angle)/cellAspect))
+			if f.Blank(x, y) {
+				f.Set(x, y, spark, style)
+			}
+		}
+	}
+}
@@ -0,0 +1,169 @@
+package game
+
+import (
+	"time"
+
+	"github.com/ashX04/gobowarrow/internal/anim"
+	"github.com/ashX04/gobowarrow/internal/assets"
+	"github.com/ashX04/gobowarrow/internal/entities"
+	"github.com/ashX04/gobowarrow/internal/physics"
+	"github.com/ashX04/gobowarrow/internal/render"
+)
+
+// Terminals only report key presses, so a held space bar is detected from
+// key repeat: presses closer together than repeatWindow mean the key is
+// held, and a gap longer than releaseGap means it was let go.
+const (
+	repeatWindow  = 120 * time.Millisecond
+	releaseGap    = 200 * time.Millisecond
+	chargeSeconds = 1.0 // time to reach a full charge
+	minCharge     = 0.3 // below this a release fires a normal arrow
+	meterWidth    = 10
+)
+
+// pressShoot handles a space press: taps fire at once, held presses charge.
+// With rapid fire there's no charging, so every key repeat is a shot.
+func (m *Game) pressShoot(now time.Time) {
+	held := now.Sub(m.lastShootPress) < repeatWindow
+	m.lastShootPress = now
+
+	if !m.bowReady() {
+		return
+	}
+
+	switch {
+	case m.charging:
+		// Key repeat while charging just keeps the charge alive
+	case held && !m.hasEffect(rapidFire):
+		m.charging = true
+		m.charge = 0
+	default:
+		m.fireArrow(0, m.aimSlope())
+	}
+}
+
+// tickCharge builds up the charge and fires once the key is released
+func (m *Game) tickCharge(now time.Time) {
+	if !m.charging {
+		return
+	}
+	m.charge = min(m.charge+1/(chargeSeconds*float64(m.cfg.TickRate)), 1)
+	if now.Sub(m.lastShootPress) > releaseGap {
+		m.fireArrow(m.charge, m.aimSlope())
+		m.charging = false
+		m.charge = 0
+	}
+}
+
+// fireArrow launches the selected arrow kind at slope, the rows it climbs
+// or drops per column
+func (m *Game) fireArrow(charge, slope float64) {
+	limit := m.difficulty.MaxArrows(m.cfg.MaxArrows)
+	if m.hasEffect(rapidFire) {
+		limit += rapidFireBonus
+	}
+	if len(m.arrows) >= limit { // Limit arrows
+		return
+	}
+	if !m.bowReady() {
+		return
+	}
+	// The practice range counts out its round's arrows
+	if m.currentMode().targets && m.shots >= rangeShots {
+		return
+	}
+	if !m.quiver.has(m.selected) {
+		m.selected = standardArrow
+	}
+	m.quiver.take(m.selected)
+	m.bow = anim.Play(assets.BowRelease)
+
+	arrow := m.newArrow(charge, slope)
+	volley := []entities.Arrow{arrow}
+	if arrow.Kind == splitArrow {
+		// Fan out into three arrows around the aimed path
+		volley = volley[:0]
+		for _, spread := range []float64{-splitSpread, 0, splitSpread} {
+			fan := arrow
+			fan.Body = physics.Launch(arrow.Body.Pos, arrow.Speed(), slope+spread)
+			volley = append(volley, fan)
+		}
+	}
+	if m.hasEffect(tripleShot) {
+		// Stack copies one row above and below each arrow
+		for _, a := range volley {
+			for _, dy := range []float64{-1, 1} {
+				extra := a
+				extra.Body.Pos.Y += dy
+				volley = append(volley, extra)
+			}
+		}
+	}
+	m.emit(event{kind: arrowFired, volley: volley})
+	for i := range volley {
+		volley[i].Prev = volley[i].Body.Pos
+		volley[i].Origin = volley[i].Body.Pos
+		m.recordShot(volley[i])
+	}
+	m.arrows = append(m.arrows, volley...)
+	// Let the camera follow the shot again after a pan
+	m.camera.panned = false
+
+	// Fall back to standard arrows once a special kind runs dry
+	if !m.quiver.has(m.selected) {
+		m.selected = standardArrow
+	}
+
+	// Rapid fire doesn't draw from the quiver
+	if m.hasEffect(rapidFire) {
+		return
+	}
+
+	// Reload automatically once the quiver is empty
+	m.arrowsLeft--
+	if m.arrowsLeft == 0 {
+		m.startReload()
+	}
+}
+
+// bowReady reports whether the bow can be drawn. It can't while reloading,
+// unless rapid fire has done away with the wait.
+func (m Game) bowReady() bool {
+	return m.hasEffect(rapidFire) || m.reloadTicks == 0 && m.arrowsLeft > 0
+}
+
+// newArrow builds an arrow leaving the bow at slope; a strong charge makes
+// it faster and lets it pierce one extra balloon
+func (m Game) newArrow(charge, slope float64) entities.Arrow {
+	speed := float64(m.cfg.ArrowSpeed) * (1 + speedStep*float64(m.upgrades[fasterArrows]))
+	arrow := entities.Arrow{
+		Kind:   m.selected,
+		Active: true,
+		Symbol: m.arrowSymbol(m.selected, false),
+	}
+	if arrow.Kind == piercingArrow {
+		arrow.Pierce = piercingHits
+	}
+	arrow.Pierce += m.upgrades[widerPierce]
+	if charge >= minCharge {
+		speed += charge * float64(m.cfg.ArrowSpeed)
+		arrow.Charge = charge
+		arrow.Pierce++
+		arrow.Symbol = m.arrowSymbol(arrow.Kind, true)
+	}
+	arrow.Body = physics.Launch(launchFrom(m.archerCol, m.archer), speed, slope)
+	return arrow
+}
+
+// drawChargeMeter places the meter in the row above the archer. The meter
+// is one styled string spanning meterWidth cells.
+func (m Game) drawChargeMeter(f *render.FrameBuffer) {
+	if !m.charging {
+		return
+	}
+	row := m.archer - 1
+	if row < 0 {
+		row = m.archer + 1
+	}
+	f.SetRaw(m.archerCol+bowReach, row, m.meter(meterWidth, m.charge, m.theme.Accent, m.theme.Danger), meterWidth)
+}
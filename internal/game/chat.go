@@ -0,0 +1,122 @@
+package game
+
+import (
+	"hash/fnv"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/ashX04/gobowarrow/internal/anim"
+	"github.com/ashX04/gobowarrow/internal/chat"
+	"github.com/ashX04/gobowarrow/internal/entities"
+	"github.com/ashX04/gobowarrow/internal/render"
+)
+
+// maxLabel is how much of a viewer's name fits under their balloon
+const maxLabel = 12
+
+// labelStyle is how a viewer's name is drawn under their balloon
+func (m Game) labelStyle() render.Style {
+	return render.Style{FG: m.theme.Text}
+}
+
+// chatSource turns chat messages into balloons. It is shared by every copy
+// of the game, so only one listener ever runs.
+type chatSource struct {
+	client    *chat.Client
+	limit     *chat.Limiter
+	listening bool
+}
+
+// viewerMsg brings on a balloon for a viewer who chatted
+type viewerMsg struct{ user string }
+
+// chatErrMsg reports that the chat connection was lost
+type chatErrMsg struct{ err error }
+
+// WithChat lets viewers in client's chat spawn balloons, as fast as limit
+// allows
+func (m Game) WithChat(client *chat.Client, limit *chat.Limiter) Game {
+	m.chat = &chatSource{client: client, limit: limit}
+	return m
+}
+
+// listenChat starts reading chat unless it's off or already being read
+func (m Game) listenChat() tea.Cmd {
+	if m.chat == nil || m.chat.listening {
+		return nil
+	}
+	m.chat.listening = true
+	return m.nextViewerBalloon()
+}
+
+// nextViewerBalloon waits for a message the limiter lets through. Its
+// balloon is made in Update, from the game as it is by then.
+func (m Game) nextViewerBalloon() tea.Cmd {
+	src := m.chat
+	return func() tea.Msg {
+		for {
+			msg, err := src.client.Next()
+			if err != nil {
+				return chatErrMsg{err: err}
+			}
+			if src.limit.Allow(msg.User, time.Now()) {
+				return viewerMsg{user: msg.User}
+			}
+		}
+	}
+}
+
+// viewerBalloon makes user's balloon. Everything about it comes from their
+// name rather than the game's RNG, which chat mustn't disturb: a seeded
+// run plays out the same whoever is watching.
+func (m Game) viewerBalloon(user string) entities.Balloon {
+	h := fnv.New32a()
+	h.Write([]byte(user))
+	roll := int(h.Sum32() >> 1)
+
+	sprites := m.balloonSprites()
+	s := sprites[roll%len(sprites)]
+	width, height := render.ArtWidth(s.Art), len(s.Art)
+	minX := (m.width + 2) / 2
+	x := float64(minX + roll%max(m.width-width-minX, 1))
+	y := float64(m.height - 1)
+
+	bob := anim.Play(balloonBob)
+	bob.Skip(roll % balloonBob.Duration())
+	return entities.Balloon{
+		Position: entities.At(x, y),
+		Art:      s.Art,
+		Color:    m.balloonColor(s),
+		Width:    width,
+		Height:   height,
+		Points:   sizeSpecs[mediumBalloon].points,
+		Speed:    sizeSpecs[mediumBalloon].speed,
+		Anim:     bob,
+		Label:    user,
+		Marker:   m.marker(s.Marker, s.Letter),
+	}
+}
+
+// shoutOutPop shouts out the viewer a balloon the host popped was named for
+func (m *Game) shoutOutPop(e event) {
+	if e.balloon.Label != "" && !e.guest {
+		m.shoutOut(e.balloon.Label)
+	}
+}
+
+// shoutOut adds a popped viewer balloon to the event feed
+func (m *Game) shoutOut(user string) {
+	m.announce("📣 Popped " + user + "'s balloon!")
+}
+
+// drawLabel writes a viewer's name under their balloon
+func drawLabel(f *render.FrameBuffer, b entities.Balloon, x, y int, style render.Style) {
+	label := []rune(b.Label)
+	if len(label) > maxLabel {
+		label = append(label[:maxLabel-1], '…')
+	}
+	text := string(label)
+	f.Text(y+b.Height, x+(b.Width-lipgloss.Width(text))/2, text, style)
+}
@@ -0,0 +1,34 @@
+package game
+
+import "testing"
+
+func TestViewerBalloonsOnlyInModesThatTakeThem(t *testing.T) {
+	for _, mode := range modes {
+		t.Run(mode.id, func(t *testing.T) {
+			g := headless(t, mode.id, 1)
+			before := len(g.balloons)
+			m, _ := g.Update(viewerMsg{user: "viewer"})
+			g = m.(Game)
+
+			want := 0
+			if mode.viewers {
+				want = 1
+			}
+			if added := len(g.balloons) - before; added != want {
+				t.Fatalf("%d balloons added, want %d", added, want)
+			}
+			if want > 0 && g.balloons[len(g.balloons)-1].Label != "viewer" {
+				t.Errorf("balloon labelled %q, want viewer", g.balloons[len(g.balloons)-1].Label)
+			}
+		})
+	}
+}
+
+func TestViewerBalloonLeavesRNGAlone(t *testing.T) {
+	g := headless(t, "classic", 1)
+	draws := g.source.draws
+	m, _ := g.Update(viewerMsg{user: "viewer"})
+	if got := m.(Game).source.draws; got != draws {
+		t.Errorf("a viewer's balloon drew %d times from the run's RNG", got-draws)
+	}
+}
@@ -0,0 +1,149 @@
+package game
+
+import (
+	"math"
+
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/ashX04/gobowarrow/internal/anim"
+	"github.com/ashX04/gobowarrow/internal/assets"
+	"github.com/ashX04/gobowarrow/internal/entities"
+	"github.com/ashX04/gobowarrow/internal/particles"
+	"github.com/ashX04/gobowarrow/internal/physics"
+	"github.com/ashX04/gobowarrow/internal/render"
+)
+
+// particleGravity pulls explosion debris gently back down
+const particleGravity = 0.05
+
+// explosionBurst is the debris thrown out when a balloon pops
+var explosionBurst = particles.Burst{
+	Min:    8,
+	Max:    12,
+	Speed:  1.2,
+	Aspect: cellAspect,
+	Life:   6,
+	Drag:   0.15,
+	Glyphs: []rune("*+·"),
+}
+
+// cutSpeedup is how much faster a balloon rises once its string is cut
+const cutSpeedup = 3
+
+// The parts of a balloon an arrow can hit
+const (
+	missed = iota
+	hitBody
+	hitString
+)
+
+// arrowReach is how many columns past its cell an arrow can strike from.
+// The arrow's shaft trails that far behind its tip.
+const arrowReach = 4
+
+// struck reports which part of balloon b, if any, an arrow in cell (ax, ay)
+// hits. A balloon with no string is all body, down to the row below it.
+func struck(b entities.Balloon, ax, ay int) int {
+	bx, by := b.Cell()
+	switch {
+	case ax+arrowReach < bx || ax > bx+b.Width || ay < by || ay > by+b.Height:
+		return missed
+	case ay < by+b.BodyHeight() || b.StringRows() == 0:
+		return hitBody
+	case b.Cut:
+		return missed
+	default:
+		return hitString
+	}
+}
+
+// cutString shoots through balloon j's string. It scores nothing and the
+// arrow flies on, but the balloon floats off faster and won't count as
+// escaping, so it can still be popped on its way.
+func (m *Game) cutString(j int) {
+	b := &m.balloons[j]
+	b.Cut = true
+	b.Speed *= cutSpeedup
+	x, _ := b.Center()
+	m.addPopup(x, b.Y+float64(b.BodyHeight()), "snip", b.Color)
+}
+
+// hitBalloon applies arrow a's impact on balloon j according to its kind
+func (m *Game) hitBalloon(a *entities.Arrow, j int) {
+	m.countHit(a)
+	a.Pops++
+	m.popBalloon(j, a, longShot(*a), a.Guest)
+	popped := []int{j}
+
+	switch a.Kind {
+	case bombArrow:
+		popped = append(popped, m.detonate(j, a.Guest)...)
+		a.Active = false
+	default:
+		// Piercing and charged arrows keep flying through their budget
+		if a.Pierce > 0 {
+			a.Pierce--
+		} else {
+			a.Active = false
+		}
+	}
+	m.chainReaction(popped, a.Guest)
+}
+
+// popBalloon bursts balloon j for the host, or with guest the guest,
+// earning any long shot bonus on it. a is the arrow that struck it, or nil
+// when a blast or chain reaction did. Scoring, achievements, trick shots,
+// power-ups, sound and particles all follow from the balloonPopped event.
+func (m *Game) popBalloon(j int, a *entities.Arrow, bonus int, guest bool) {
+	b := &m.balloons[j]
+	b.Popped = true
+	m.emit(event{kind: balloonPopped, balloon: b, arrow: a, bonus: bonus, guest: guest})
+	b.Anim = anim.Play(assets.Explosion)
+	b.Despawn = despawnTicks
+}
+
+// burstPop bursts a popped balloon into particles
+func (m *Game) burstPop(e event) {
+	x, y := e.balloon.Center()
+	m.explode(x, y, e.balloon.Color)
+}
+
+// detonate pops every balloon within bombRadius of balloon j's center,
+// for whichever archer fired the bomb, and returns the ones it popped
+func (m *Game) detonate(j int, guest bool) []int {
+	var popped []int
+	cx, cy := m.balloons[j].Center()
+	for k := range m.balloons {
+		if m.balloons[k].Popped {
+			continue
+		}
+		x, y := m.balloons[k].Center()
+		// Rows are roughly twice as tall as columns are wide
+		if math.Hypot(x-cx, (y-cy)*cellAspect) <= bombRadius {
+			m.popBalloon(k, nil, 0, guest)
+			popped = append(popped, k)
+		}
+	}
+	return popped
+}
+
+// explode sprays debris in the balloon's color from the cell (x, y)
+func (m *Game) explode(x, y float64, color lipgloss.Color) {
+	burst := explosionBurst
+	burst.Color = string(color)
+	m.particles.Emit(m.rng, physics.Vec{X: x, Y: y}, burst)
+}
+
+// drawParticles renders live particles onto empty board cells
+func (m Game) drawParticles(f *render.FrameBuffer) {
+	if m.cfg.ReducedMotion {
+		return
+	}
+	for _, p := range m.particles.Particles() {
+		x, y := p.Pos.Cell()
+		if !f.Blank(x, y) {
+			continue
+		}
+		f.Set(x, y, string(p.Glyph()), render.Style{FG: lipgloss.Color(p.Color), Faint: p.Fading()})
+	}
+}
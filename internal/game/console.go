@@ -0,0 +1,229 @@
+package game
+
+import (
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+	"strconv"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/ashX04/gobowarrow/internal/assets"
+	"github.com/ashX04/gobowarrow/internal/entities"
+)
+
+// Console tuning
+const (
+	consoleWidth = 40 // longest command it takes
+	consoleLines = 8  // replies kept on screen
+	maxSpawn     = 50 // most balloons one spawn command brings up
+)
+
+// consoleCommand is something the developer console can be told to do.
+// run changes the game and returns what the console replies.
+type consoleCommand struct {
+	usage string // how it's called, for help
+	run   func(m *Game, args []string) (string, error)
+}
+
+// consoleCommands are the console's commands by name. help is answered by
+// the console itself.
+var consoleCommands = map[string]consoleCommand{
+	"spawn": {usage: "spawn <golden|balloon|sprite name> [count]", run: (*Game).consoleSpawn},
+	"set":   {usage: "set <setting> <value>", run: (*Game).consoleSet},
+	"god":   {usage: "god", run: (*Game).consoleGod},
+	"wave":  {usage: "wave <n>", run: (*Game).consoleWave},
+	"seed":  {usage: "seed <n>", run: (*Game).consoleSeed},
+}
+
+// consoleSetting is a tunable the set command changes for the rest of the
+// run
+type consoleSetting struct {
+	min, max float64
+	set      func(m *Game, v float64)
+}
+
+// consoleSettings are what set can change, by name
+var consoleSettings = map[string]consoleSetting{
+	"spawnrate":  {min: 0, max: 1, set: func(m *Game, v float64) { m.cfg.SpawnChance = v }},
+	"gravity":    {min: 0, max: 100, set: func(m *Game, v float64) { m.cfg.Gravity = v }},
+	"arrowspeed": {min: 1, max: 500, set: func(m *Game, v float64) { m.cfg.ArrowSpeed = int(v) }},
+	"maxarrows":  {min: 1, max: 100, set: func(m *Game, v float64) { m.cfg.MaxArrows = int(v) }},
+	"coins":      {min: 0, max: 1e6, set: func(m *Game, v float64) { m.coins = int(v) }},
+}
+
+// errUsage means a command was called wrongly; the console shows its usage
+var errUsage = errors.New("usage")
+
+// hasConsole reports whether the console can be opened: during a run,
+// unless it's a daily one, which everyone has to play straight, or a
+// networked match
+func (m Game) hasConsole() bool {
+	return (m.state == playing || m.state == paused) && !m.currentMode().daily && m.guest == nil
+}
+
+// openConsole shows the console over the board, holding the run
+func (m *Game) openConsole() {
+	m.dialog = dialog{
+		kind:  consoleDialog,
+		title: "Console",
+		lines: m.consoleLog,
+		limit: consoleWidth,
+		hint:  "ENTER run · ESC close · help lists commands",
+	}
+}
+
+// updateConsole handles a key press while the console is open
+func (m Game) updateConsole(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	d := &m.dialog
+	switch {
+	case msg.Type == tea.KeyEsc || msg.String() == "~" || msg.String() == "`":
+		m.dialog = dialog{}
+	case msg.Type == tea.KeyBackspace:
+		if len(d.field) > 0 {
+			d.field = d.field[:len(d.field)-1]
+		}
+	case msg.Type == tea.KeyEnter:
+		line := d.field
+		m.consoleLog = append(m.consoleLog, "> "+line)
+		if reply := m.execute(line); reply != "" {
+			m.consoleLog = append(m.consoleLog, reply)
+		}
+		m.consoleLog = m.consoleLog[max(len(m.consoleLog)-consoleLines, 0):]
+		m.openConsole()
+	case msg.Type == tea.KeyRunes || msg.Type == tea.KeySpace:
+		for _, r := range msg.Runes {
+			if len(d.field) < d.limit && r < 0x80 {
+				d.field += string(r)
+			}
+		}
+	}
+	return m, nil
+}
+
+// execute runs a line typed into the console and returns its reply. Any
+// command that changes the game marks the run as cheated.
+func (m *Game) execute(line string) string {
+	words := strings.Fields(strings.ToLower(line))
+	if len(words) == 0 {
+		return ""
+	}
+	name, args := words[0], words[1:]
+	if name == "help" {
+		names := slices.Sorted(maps.Keys(consoleCommands))
+		return "Commands: help " + strings.Join(names, " ")
+	}
+	cmd, ok := consoleCommands[name]
+	if !ok {
+		return fmt.Sprintf("Unknown command %q; try help", name)
+	}
+	reply, err := cmd.run(m, args)
+	switch {
+	case errors.Is(err, errUsage):
+		return "Usage: " + cmd.usage
+	case err != nil:
+		return err.Error()
+	}
+	m.cheated = true
+	return reply
+}
+
+// consoleSpawn brings up balloons along the bottom of the board: golden
+// ones, ones of a named sprite, or ones picked as a wave would
+func (m *Game) consoleSpawn(args []string) (string, error) {
+	if len(args) < 1 || len(args) > 2 {
+		return "", errUsage
+	}
+	count := 1
+	if len(args) == 2 {
+		n, err := strconv.Atoi(args[1])
+		if err != nil || n < 1 {
+			return "", errUsage
+		}
+		count = min(n, maxSpawn)
+	}
+	kind, sprites := args[0], m.balloonSprites()
+	named := slices.IndexFunc(sprites, func(s assets.Sprite) bool { return strings.EqualFold(s.Name, kind) })
+	if kind != "golden" && kind != "balloon" && named < 0 {
+		return "", fmt.Errorf("No balloon called %q", kind)
+	}
+	for range count {
+		var b entities.Balloon
+		switch {
+		case kind == "golden":
+			b = m.newGoldenBalloon()
+		case named >= 0:
+			b = m.newSizedBalloon(sprites[named], m.pickSize())
+		default:
+			b = m.newSizedBalloon(sprites[m.rng.Intn(len(sprites))], m.pickSize())
+		}
+		m.balloons = append(m.balloons, b)
+	}
+	return fmt.Sprintf("Spawned %d %s", count, kind), nil
+}
+
+// consoleSet changes one of consoleSettings for the rest of the run
+func (m *Game) consoleSet(args []string) (string, error) {
+	if len(args) != 2 {
+		return "", errUsage
+	}
+	s, ok := consoleSettings[args[0]]
+	if !ok {
+		names := slices.Sorted(maps.Keys(consoleSettings))
+		return "", fmt.Errorf("Settings: %s", strings.Join(names, " "))
+	}
+	v, err := strconv.ParseFloat(args[1], 64)
+	if err != nil {
+		return "", errUsage
+	}
+	if v < s.min || v > s.max {
+		return "", fmt.Errorf("%s must be between %g and %g", args[0], s.min, s.max)
+	}
+	s.set(m, v)
+	return fmt.Sprintf("%s = %g", args[0], v), nil
+}
+
+// consoleGod turns off every way the run can end but its clock or a
+// level's goal
+func (m *Game) consoleGod(args []string) (string, error) {
+	if len(args) != 0 {
+		return "", errUsage
+	}
+	m.god = !m.god
+	if m.god {
+		return "God mode on", nil
+	}
+	return "God mode off", nil
+}
+
+// consoleWave jumps straight to the start of wave n
+func (m *Game) consoleWave(args []string) (string, error) {
+	if len(args) != 1 {
+		return "", errUsage
+	}
+	n, err := strconv.Atoi(args[0])
+	if err != nil || n < 1 {
+		return "", errUsage
+	}
+	m.wave = newWave(n)
+	m.waveEscaped = 0
+	m.showBanner(fmt.Sprintf("Wave %d", n))
+	return fmt.Sprintf("Wave %d", n), nil
+}
+
+// consoleSeed reseeds the run's RNG, so what spawns from here on plays
+// out the same for the same seed
+func (m *Game) consoleSeed(args []string) (string, error) {
+	if len(args) != 1 {
+		return "", errUsage
+	}
+	seed, err := strconv.ParseInt(args[0], 10, 64)
+	if err != nil {
+		return "", errUsage
+	}
+	m.seed = seed
+	m.rng, m.source = newRNG(seed)
+	return fmt.Sprintf("Seed %d", seed), nil
+}
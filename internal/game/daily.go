@@ -0,0 +1,134 @@
+package game
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/ashX04/gobowarrow/internal/config"
+	"github.com/ashX04/gobowarrow/internal/daily"
+)
+
+// calendarWeeks is how many weeks of daily results the calendar shows
+const calendarWeeks = 4
+
+// dailySavedMsg reports the result of writing the daily history
+type dailySavedMsg struct{ err error }
+
+// dailyConfig locks everything that affects the balloons to the defaults
+// and seeds the run from day, so every player sees the same challenge.
+// Only settings that change how the board looks or the controls are kept.
+func dailyConfig(cfg config.Config, day time.Time) config.Config {
+	locked := config.Default()
+	locked.FrameRate = cfg.FrameRate
+	locked.Renderer = cfg.Renderer
+	locked.MoveRepeat = cfg.MoveRepeat
+	locked.Keys = cfg.Keys
+	locked.Theme = cfg.Theme
+	locked.Themes = cfg.Themes
+	locked.Accessible = cfg.Accessible
+	locked.Monochrome = cfg.Monochrome
+	locked.ASCII = cfg.ASCII
+	locked.ReducedMotion = cfg.ReducedMotion
+	locked.Scenery = cfg.Scenery
+	locked.DayLength = cfg.DayLength
+	locked.Seed = daily.Seed(day)
+	return locked
+}
+
+// dailyPlayed reports whether today's challenge has already been attempted
+func (m Game) dailyPlayed() (daily.Result, bool) {
+	return m.dailyHistory.On(daily.Today())
+}
+
+// startRun begins a run in the selected mode, if the player's level has
+// unlocked it. The daily challenge allows one attempt per day, which
+// counts as soon as it starts so quitting early can't be used to retry it.
+func (m Game) startRun() (Game, tea.Cmd) {
+	if m.modeLocked() {
+		return m, nil
+	}
+	if m.currentMode().daily {
+		if _, played := m.dailyPlayed(); played {
+			return m, nil
+		}
+	}
+	m = m.restart()
+	m.theme = m.runTheme(m.playerCfg.Theme, m.playerCfg.Themes)
+	return m, tea.Batch(m.Init(), m.recordDaily(false))
+}
+
+// recordDaily stores the daily run's score, if this is a daily run
+func (m *Game) recordDaily(finished bool) tea.Cmd {
+	if m.dailyDay.IsZero() {
+		return nil
+	}
+	m.dailyHistory = m.dailyHistory.Set(daily.Result{
+		Date:     m.dailyDay.Format(daily.DateFormat),
+		Score:    m.score,
+		Finished: finished,
+	})
+	if m.dailyPath == "" {
+		return nil
+	}
+	path, history := m.dailyPath, m.dailyHistory
+	return func() tea.Msg {
+		if err := history.Save(path); err != nil {
+			return dailySavedMsg{err: fmt.Errorf("daily: %w", err)}
+		}
+		return dailySavedMsg{}
+	}
+}
+
+// dailyCalendar lays out the last few weeks of daily results, Monday
+// first, ending with the week containing today
+func (m Game) dailyCalendar(h daily.History, today time.Time) string {
+	headerStyle := lipgloss.NewStyle().Foreground(m.theme.Muted)
+	todayStyle := lipgloss.NewStyle().Foreground(m.theme.Accent).Bold(true)
+	missedStyle := lipgloss.NewStyle().Foreground(m.theme.Faint)
+
+	const cellWidth = 6
+	cell := func(s string) string {
+		return fmt.Sprintf("%*s", cellWidth, s)
+	}
+
+	var header strings.Builder
+	for _, day := range []string{"Mon", "Tue", "Wed", "Thu", "Fri", "Sat", "Sun"} {
+		header.WriteString(cell(day))
+	}
+	rows := []string{headerStyle.Render(header.String())}
+
+	// Weekday counts from Sunday; shift so weeks start on Monday
+	offset := (int(today.Weekday()) + 6) % 7
+	start := today.AddDate(0, 0, -offset-7*(calendarWeeks-1))
+	for week := range calendarWeeks {
+		var row strings.Builder
+		for i := range 7 {
+			day := start.AddDate(0, 0, week*7+i)
+			r, played := h.On(day)
+			switch {
+			case day.After(today):
+				row.WriteString(cell(""))
+			case day.Equal(today) && played:
+				row.WriteString(todayStyle.Render(cell(fmt.Sprint(r.Score))))
+			case day.Equal(today):
+				row.WriteString(todayStyle.Render(cell("?")))
+			case !played:
+				row.WriteString(missedStyle.Render(cell("·")))
+			case !r.Finished:
+				row.WriteString(missedStyle.Render(cell("✗")))
+			default:
+				row.WriteString(cell(fmt.Sprint(r.Score)))
+			}
+		}
+		rows = append(rows, row.String())
+	}
+
+	rows = append(rows, headerStyle.Render(fmt.Sprintf(
+		"\nDays played: %d   Best: %d", len(h), h.Best(),
+	)))
+	return strings.Join(rows, "\n")
+}
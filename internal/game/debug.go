@@ -0,0 +1,92 @@
+package game
+
+import (
+	"fmt"
+	"runtime"
+	"time"
+
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/ashX04/gobowarrow/internal/render"
+)
+
+// debugSample is how often the debug overlay works its rates out
+const debugSample = time.Second
+
+// debugStats is what the debug overlay shows about the engine. Games share
+// it by pointer so View, which can't change the game, can note how long it
+// took.
+type debugStats struct {
+	since         time.Time     // start of the current sample
+	ticks, frames int           // run so far this sample
+	mallocs       uint64        // allocations made before the sample started
+	tps, fps      float64       // rates over the last whole sample
+	allocs        float64       // allocations a second over the last sample
+	heap          uint64        // bytes of live heap at the last sample
+	collections   uint32        // garbage collections so far
+	render        time.Duration // how long the last frame took to draw
+}
+
+// toggleDebug shows or hides the debug overlay
+func (m *Game) toggleDebug() {
+	if m.debug != nil {
+		m.debug = nil
+		return
+	}
+	m.debug = &debugStats{}
+}
+
+// sampleDebug counts a frame, working the rates out once a sample is up
+func (m Game) sampleDebug(now time.Time) {
+	d := m.debug
+	if d == nil {
+		return
+	}
+	d.frames++
+	elapsed := now.Sub(d.since)
+	if elapsed < debugSample && !d.since.IsZero() {
+		return
+	}
+	// Reading the memory stats stops the world briefly, so it's only done
+	// once a sample
+	var mem runtime.MemStats
+	runtime.ReadMemStats(&mem)
+	if !d.since.IsZero() {
+		secs := elapsed.Seconds()
+		d.tps, d.fps = float64(d.ticks)/secs, float64(d.frames)/secs
+		d.allocs = float64(mem.Mallocs-d.mallocs) / secs
+	}
+	d.heap, d.collections = mem.HeapAlloc, mem.NumGC
+	d.since, d.ticks, d.frames, d.mallocs = now, 0, 0, mem.Mallocs
+}
+
+// timeRender notes how long drawing a frame begun at start took
+func (d *debugStats) timeRender(start time.Time) {
+	d.render = time.Since(start)
+}
+
+// drawDebug writes the debug overlay into the board's top right corner
+func (m Game) drawDebug(board *render.FrameBuffer) {
+	d := m.debug
+	if d == nil {
+		return
+	}
+	lines := []string{
+		fmt.Sprintf("TPS %.1f/%d  FPS %.1f", d.tps, m.cfg.TickRate, d.fps),
+		fmt.Sprintf("Render %s", d.render.Round(time.Microsecond)),
+		fmt.Sprintf("Arrows %d  Balloons %d", len(m.arrows), len(m.balloons)),
+		fmt.Sprintf("Birds %d  Particles %d", len(m.birds), m.particles.Len()),
+		fmt.Sprintf("Heap %.1f MB  GCs %d", float64(d.heap)/(1<<20), d.collections),
+		fmt.Sprintf("Allocs %.0f/s", d.allocs),
+		fmt.Sprintf("Seed %d", m.seed),
+	}
+	width := 0
+	for _, line := range lines {
+		width = max(width, lipgloss.Width(line))
+	}
+	style := render.Style{FG: m.theme.Contrast, BG: m.theme.Border}
+	col := max(board.Width()-width-2, 0)
+	for row, line := range lines {
+		board.Text(row, col, fmt.Sprintf(" %-*s ", width, line), style)
+	}
+}
@@ -0,0 +1,187 @@
+package game
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/ashX04/gobowarrow/internal/render"
+	"github.com/ashX04/gobowarrow/internal/scores"
+)
+
+// dialogKind says what a dialog is for, and so what its keys do
+type dialogKind int
+
+const (
+	noDialog      dialogKind = iota
+	quitDialog               // confirms leaving a run part way through
+	levelDialog              // sums up a completed level
+	nameDialog               // takes initials for a new high score
+	shopDialog               // sells upgrades between waves
+	consoleDialog            // takes developer console commands
+)
+
+// dialog is a box drawn over the board. While one is open it takes every
+// key, and the board behind it is dimmed and held still.
+type dialog struct {
+	kind  dialogKind
+	title string
+	lines []string // the message under the title
+	limit int      // longest answer it prompts for; 0 when it doesn't
+	field string   // the answer typed so far
+	hint  string   // the keys it answers to
+}
+
+// open reports whether the dialog is showing
+func (d dialog) open() bool {
+	return d.kind != noDialog
+}
+
+// askQuit asks before throwing a run away, or leaving one that will be
+// saved to resume
+func (m *Game) askQuit() {
+	line := "This run will be lost."
+	if m.canSave() {
+		line = "This run will be saved to resume next time."
+	}
+	m.dialog = dialog{
+		kind:  quitDialog,
+		title: "Quit?",
+		lines: []string{line},
+		hint:  "y quit · n keep playing",
+	}
+}
+
+// wrapUp shows whatever dialogs a finished run calls for before the game
+// over screen: the level's summary, then the high score prompt
+func (m *Game) wrapUp() {
+	switch {
+	case m.won && m.dialog.kind != levelDialog:
+		m.dialog = dialog{
+			kind:  levelDialog,
+			title: "🏆 Level complete 🏆",
+			lines: []string{
+				m.level.Name,
+				fmt.Sprintf("Score: %d", m.score),
+				fmt.Sprintf("Accuracy: %.0f%%", m.accuracy()),
+				fmt.Sprintf("Best combo: %d", m.bestCombo),
+			},
+			hint: "ENTER continue",
+		}
+	case !m.currentMode().daily && !m.cheated && m.highScores.Qualifies(m.currentMode().id, m.score):
+		m.dialog = dialog{
+			kind:  nameDialog,
+			title: "New high score!",
+			lines: []string{"Enter your initials:"},
+			limit: maxInitials,
+			hint:  "ENTER to save",
+		}
+	default:
+		m.dialog = dialog{}
+		m.state = gameOver
+	}
+}
+
+// quits reports whether msg ends the program rather than asking first
+func (m Game) quits(msg tea.KeyMsg) bool {
+	switch {
+	case msg.String() == "ctrl+c":
+		return true
+	case m.dialog.open():
+		return m.dialog.kind == quitDialog && msg.String() == "y"
+	default:
+		return m.state == gameOver && key.Matches(msg, m.keys.quit)
+	}
+}
+
+// updateDialog handles a key press while a dialog is open
+func (m Game) updateDialog(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	if msg.Type == tea.KeyCtrlC {
+		return m, m.quit()
+	}
+	switch m.dialog.kind {
+	case quitDialog:
+		switch msg.String() {
+		case "y":
+			return m, m.quit()
+		case "n", "esc":
+			m.dialog = dialog{}
+		}
+	case levelDialog:
+		if msg.Type == tea.KeyEnter {
+			m.wrapUp()
+		}
+	case nameDialog:
+		return m.updateNameEntry(msg)
+	case shopDialog:
+		return m.updateShop(msg)
+	case consoleDialog:
+		return m.updateConsole(msg)
+	}
+	return m, nil
+}
+
+// updateNameEntry handles typing initials for a new high score
+func (m Game) updateNameEntry(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	d := &m.dialog
+	switch msg.Type {
+	case tea.KeyBackspace:
+		if len(d.field) > 0 {
+			d.field = d.field[:len(d.field)-1]
+		}
+	case tea.KeyEnter:
+		if d.field == "" {
+			return m, nil
+		}
+		m.highScores = m.highScores.Add(scores.Entry{
+			Mode:     m.currentMode().id,
+			Initials: d.field,
+			Score:    m.score,
+			Time:     time.Now(),
+		})
+		m.dialog = dialog{}
+		m.state = gameOver
+		return m, saveScores(m.scoresPath, m.highScores)
+	case tea.KeyRunes:
+		for _, r := range strings.ToUpper(string(msg.Runes)) {
+			if len(d.field) < d.limit && (r >= 'A' && r <= 'Z' || r >= '0' && r <= '9') {
+				d.field += string(r)
+			}
+		}
+	}
+	return m, nil
+}
+
+// drawDialog boxes the open dialog in the middle of the board
+func (m Game) drawDialog(f *render.FrameBuffer) {
+	d := m.dialog
+	if !d.open() {
+		return
+	}
+	lines := append([]string{d.title, ""}, d.lines...)
+	if d.limit > 0 {
+		lines = append(lines, d.field+strings.Repeat("_", d.limit-len(d.field)))
+	}
+	lines = append(lines, "", d.hint)
+
+	width := 0
+	for _, line := range lines {
+		width = max(width, lipgloss.Width(line))
+	}
+	style := render.Style{FG: m.theme.Contrast, BG: m.theme.Border}
+	top := max((f.Height()-len(lines)-2)/2, 0)
+	left := max((f.Width()-width-4)/2, 0)
+	f.Text(top, left, "╭"+strings.Repeat("─", width+2)+"╮", style)
+	for i, line := range lines {
+		pad := width - lipgloss.Width(line)
+		text := strings.Repeat(" ", pad/2) + line + strings.Repeat(" ", pad-pad/2)
+		lineStyle := style
+		lineStyle.Bold = i == 0
+		f.Text(top+1+i, left, "│ "+text+" │", lineStyle)
+	}
+	f.Text(top+1+len(lines), left, "╰"+strings.Repeat("─", width+2)+"╯", style)
+}
@@ -0,0 +1,155 @@
+package game
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/ashX04/gobowarrow/internal/assets"
+	"github.com/ashX04/gobowarrow/internal/entities"
+	"github.com/ashX04/gobowarrow/internal/render"
+)
+
+// effectKind identifies a temporary buff granted by a power-up balloon
+type effectKind = entities.PowerUp
+
+const (
+	noEffect effectKind = iota
+	rapidFire
+	tripleShot
+	slowMotion
+	scoreDoubler
+	timeFreeze
+	effectKindCount
+)
+
+// powerUpChance is how often a wave balloon spawns as a power-up
+const powerUpChance = 0.08
+
+// rapidFireBonus is how many extra arrows may be in flight with rapid fire
+const rapidFireBonus = 3
+
+// slowMotionScale is how fast the game runs in slow motion
+const slowMotionScale = 0.5
+
+// effectSpec describes a buff and the balloon that grants it
+type effectSpec struct {
+	name     string
+	icon     string
+	marker   string // one-cell symbol on its balloon in accessible mode
+	letter   string // the marker in plain ASCII
+	color    lipgloss.Color
+	duration float64 // seconds
+}
+
+var effectSpecs = [effectKindCount]effectSpec{
+	rapidFire:    {name: "Rapid fire", icon: "⚡", marker: "»", letter: ">", color: "226", duration: 8},
+	tripleShot:   {name: "Triple shot", icon: "≡", marker: "≡", letter: "=", color: "51", duration: 8},
+	slowMotion:   {name: "Slow motion", icon: "◷", marker: "◷", letter: "@", color: "141", duration: 5},
+	scoreDoubler: {name: "Double points", icon: "×2", marker: "×", letter: "x", color: "214", duration: 10},
+	timeFreeze:   {name: "Time freeze", icon: "❄", marker: "❄", letter: "*", color: "117", duration: 3},
+}
+
+// effect is an active buff counting down to expiry
+type effect struct {
+	kind      effectKind
+	ticksLeft int
+}
+
+// addEffect starts a buff, or refreshes its timer if already active
+func (m *Game) addEffect(kind effectKind) {
+	ticks := int(effectSpecs[kind].duration * float64(m.cfg.TickRate))
+	for i := range m.effects {
+		if m.effects[i].kind == kind {
+			m.effects[i].ticksLeft = ticks
+			return
+		}
+	}
+	m.effects = append(m.effects, effect{kind: kind, ticksLeft: ticks})
+}
+
+// tickEffects counts buffs down and drops the expired ones
+func (m *Game) tickEffects() {
+	active := m.effects[:0]
+	for _, e := range m.effects {
+		e.ticksLeft--
+		if e.ticksLeft > 0 {
+			active = append(active, e)
+		}
+	}
+	m.effects = active
+}
+
+// grantPowerUp starts the buff a power-up balloon the host popped carries.
+// The guest has no buffs, so one they pop is wasted.
+func (m *Game) grantPowerUp(e event) {
+	if e.balloon.PowerUp == noEffect || e.guest {
+		return
+	}
+	m.addEffect(e.balloon.PowerUp)
+	spec := effectSpecs[e.balloon.PowerUp]
+	m.notify(spec.icon+" "+spec.name+"!", spec.color)
+}
+
+// hasEffect reports whether a buff is currently active
+func (m Game) hasEffect(kind effectKind) bool {
+	for _, e := range m.effects {
+		if e.kind == kind {
+			return true
+		}
+	}
+	return false
+}
+
+// timeScale is how many seconds of play each tick moves the game on by,
+// as a fraction of the tick's length. Buffs still count down in real time.
+func (m Game) timeScale() float64 {
+	if m.hasEffect(slowMotion) {
+		return slowMotionScale
+	}
+	return 1
+}
+
+// frozen reports whether time freeze is holding the balloons where they
+// are. Arrows, birds and the archer carry on.
+func (m Game) frozen() bool {
+	return m.hasEffect(timeFreeze)
+}
+
+// maybePowerUp turns a freshly spawned balloon into a power-up at random
+func (m Game) maybePowerUp(b entities.Balloon) entities.Balloon {
+	if b.Golden || m.rng.Float64() >= powerUpChance {
+		return b
+	}
+	kind := effectKind(1 + m.rng.Intn(int(effectKindCount)-1))
+	b.PowerUp = kind
+	b.Art = assets.PowerUp
+	b.Color = effectSpecs[kind].color
+	b.Marker = m.marker(effectSpecs[kind].marker, effectSpecs[kind].letter)
+	b.Width = render.ArtWidth(assets.PowerUp)
+	b.Height = len(assets.PowerUp)
+	return b
+}
+
+// effectsView renders a badge with the seconds left for each active buff,
+// one to a line beside the board
+func (m Game) effectsView(side bool) string {
+	if len(m.effects) == 0 {
+		return ""
+	}
+	badges := make([]string, len(m.effects))
+	for i, e := range m.effects {
+		spec := effectSpecs[e.kind]
+		style := lipgloss.NewStyle().
+			Foreground(lipgloss.Color("232")).
+			Background(spec.color).
+			Padding(0, 1)
+		seconds := (e.ticksLeft + m.cfg.TickRate - 1) / m.cfg.TickRate
+		badges[i] = style.Render(fmt.Sprintf("%s %ds", spec.icon, seconds))
+	}
+	if side {
+		return strings.Join(badges, "\n")
+	}
+	return strings.Join(badges, " ")
+}
@@ -0,0 +1,58 @@
+package game
+
+import "github.com/ashX04/gobowarrow/internal/entities"
+
+// eventKind identifies something that happened during a tick that other
+// parts of the game react to
+type eventKind int
+
+const (
+	balloonPopped eventKind = iota // by an arrow, a blast or a chain reaction
+	arrowFired                     // a volley left the bow
+	waveCompleted                  // the board was cleared after a wave's last spawn
+	lifeLost                       // a balloon escaped in a mode with lives
+	eventKindCount
+)
+
+// event is one thing that happened. Which fields are set depends on its
+// kind.
+type event struct {
+	kind    eventKind
+	balloon *entities.Balloon // balloonPopped: the balloon, already marked popped
+	bonus   int               // balloonPopped: the long shot bonus it earned
+	arrow   *entities.Arrow   // balloonPopped: the arrow that struck it; nil for blasts and chains
+	guest   bool              // balloonPopped: by the guest's arrow in a networked match
+	volley  []entities.Arrow  // arrowFired: every arrow fired at once
+	wave    int               // waveCompleted: the wave's number
+	escaped int               // waveCompleted: balloons that got away during it
+}
+
+// bus is the systems each kind of event is handed to, in the order they
+// run. The order is part of the simulation: handlers that draw from the
+// RNG must keep theirs so seeded runs replay the same. Every game has its
+// own, so games sharing a process, such as SSH sessions, never hand each
+// other events.
+type bus [eventKindCount][]func(m *Game, e event)
+
+// newBus subscribes the game's systems to the events they react to
+func newBus() bus {
+	var b bus
+	b.subscribe(balloonPopped, (*Game).countPop, (*Game).popSound, (*Game).scorePop, (*Game).recordPop,
+		(*Game).grantPowerUp, (*Game).shoutOutPop, (*Game).burstPop, (*Game).judgeTricks)
+	b.subscribe(arrowFired, (*Game).shotSound, (*Game).countShots)
+	b.subscribe(waveCompleted, (*Game).recordWave)
+	b.subscribe(lifeLost, (*Game).announceLifeLost)
+	return b
+}
+
+// subscribe hands events of kind to each handler in turn
+func (b *bus) subscribe(kind eventKind, handlers ...func(m *Game, e event)) {
+	b[kind] = append(b[kind], handlers...)
+}
+
+// emit hands e to everything subscribed to its kind, straight away
+func (m *Game) emit(e event) {
+	for _, h := range m.bus[e.kind] {
+		h(m, e)
+	}
+}
@@ -0,0 +1,73 @@
+package game
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/ashX04/gobowarrow/internal/entities"
+)
+
+func TestGamesHaveTheirOwnBus(t *testing.T) {
+	a, b := headless(t, "classic", 1), headless(t, "classic", 1)
+	heard := 0
+	a.bus.subscribe(lifeLost, func(*Game, event) { heard++ })
+
+	b.emit(event{kind: lifeLost})
+	if heard != 0 {
+		t.Fatal("an event emitted by one game reached another's subscriber")
+	}
+	a.emit(event{kind: lifeLost})
+	if heard != 1 {
+		t.Errorf("subscriber heard %d events, want 1", heard)
+	}
+}
+
+// boardWithBalloon sets up a classic run with one balloon, well clear of
+// the top row, on the board
+func boardWithBalloon(t *testing.T) Game {
+	t.Helper()
+	g := headless(t, "classic", 1)
+	b := g.newGoldenBalloon()
+	b.Y = 5
+	g.balloons = []entities.Balloon{b}
+	return g
+}
+
+func TestArrowPopIsJudgedForTricks(t *testing.T) {
+	g := boardWithBalloon(t)
+
+	// The arrow has already popped one balloon, so this is a Skewer
+	a := entities.Arrow{Kind: standardArrow, Active: true, Pops: 1}
+	g.hitBalloon(&a, 0)
+
+	if !strings.Contains(strings.Join(g.feed.recent(feedSize), "\n"), "Skewer") {
+		t.Errorf("feed = %q, want a Skewer", g.feed.recent(feedSize))
+	}
+}
+
+func TestBlastPopIsNotJudgedForTricks(t *testing.T) {
+	g := boardWithBalloon(t)
+	g.movedAt, g.timer = 1, 1
+
+	g.popBalloon(0, nil, 0, false)
+
+	for _, news := range g.feed.recent(feedSize) {
+		if strings.HasPrefix(news, "🎯") {
+			t.Errorf("a blast pop was judged a trick shot: %q", news)
+		}
+	}
+}
+
+func TestLifeLostCountsBoughtLives(t *testing.T) {
+	g := headless(t, "survival", 1)
+	g.upgrades[extraLife] = 1
+	g.lives = g.maxLives() - 1
+
+	g.emit(event{kind: lifeLost})
+
+	news := g.feed.recent(1)
+	want := hearts(g.lives, g.currentMode().lives+1)
+	if len(news) != 1 || !strings.HasSuffix(news[0], want) {
+		t.Errorf("feed = %q, want it ending %s", news, want)
+	}
+}
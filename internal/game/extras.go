@@ -0,0 +1,107 @@
+package game
+
+import (
+	"slices"
+
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/ashX04/gobowarrow/extension"
+	"github.com/ashX04/gobowarrow/internal/entities"
+	"github.com/ashX04/gobowarrow/internal/render"
+	"github.com/ashX04/gobowarrow/internal/sound"
+)
+
+// WithExtensions has the waves bring on the kinds of entity plugins add,
+// alongside the built-in balloons
+func (m Game) WithExtensions(kinds []extension.Kind) Game {
+	m.kinds = kinds
+	return m
+}
+
+// spawnExtras gives each plugin kind its chance to come on this tick.
+// The daily challenge is played with the built-in balloons alone.
+func (m *Game) spawnExtras() {
+	if m.currentMode().daily {
+		return
+	}
+	for _, k := range m.kinds {
+		if m.wave.number < k.FirstWave || m.rng.Float64() >= k.Chance {
+			continue
+		}
+		if e := k.New(extrasWorld{m}); e != nil {
+			m.extras = append(m.extras, e)
+		}
+	}
+}
+
+// tickExtras moves the plugin entities on and lets go of the finished
+// ones, in a new slice so copies of the game holding the old one are left
+// alone
+func (m *Game) tickExtras(dt float64) {
+	extras := make([]extension.Entity, 0, len(m.extras))
+	for _, e := range m.extras {
+		if e.Update(extrasWorld{m}, dt) {
+			extras = append(extras, e)
+		}
+	}
+	m.extras = extras
+}
+
+// hitExtras checks arrow a, with its tip at (ax, ay), against the plugin
+// entities. What each one does to it is up to the entity.
+func (m *Game) hitExtras(a *entities.Arrow, ax, ay int) {
+	for i := 0; i < len(m.extras) && a.Active; i++ {
+		h, ok := m.extras[i].Collide(ax, ay)
+		if !ok {
+			continue
+		}
+		if h.Points > 0 && !m.currentMode().zen {
+			m.countHit(a)
+			points, bonus, multiplier := m.registerHit(h.Points, 0, a.Guest)
+			m.addPopup(float64(ax), float64(ay), scorePopup(points, bonus, multiplier), "226")
+			m.playSound(sound.Pop)
+		}
+		if h.Remove {
+			m.extras = slices.Concat(m.extras[:i], m.extras[i+1:])
+			i--
+		}
+		if h.Stop {
+			a.Active = false
+		}
+	}
+}
+
+// drawExtras draws the plugin entities
+func (m Game) drawExtras(f *render.FrameBuffer, dim bool) {
+	c := extrasCanvas{f: f, text: m.theme.Text, dim: dim, dimStyle: m.dimStyle()}
+	for _, e := range m.extras {
+		e.Render(c)
+	}
+}
+
+// extrasWorld is the run as a plugin entity sees it
+type extrasWorld struct{ m *Game }
+
+func (w extrasWorld) Width() int       { return w.m.width }
+func (w extrasWorld) Height() int      { return w.m.height }
+func (w extrasWorld) Wind() float64    { return w.m.applyWind() }
+func (w extrasWorld) Random(n int) int { return w.m.rng.Intn(max(n, 1)) }
+
+// extrasCanvas draws plugin entities on the board
+type extrasCanvas struct {
+	f        *render.FrameBuffer
+	text     lipgloss.TerminalColor // color for text drawn without one
+	dim      bool                   // the board is paused, so everything is drawn in dimStyle
+	dimStyle render.Style
+}
+
+func (c extrasCanvas) Text(x, y int, text, color string) {
+	style := render.Style{FG: c.text}
+	switch {
+	case c.dim:
+		style = c.dimStyle
+	case color != "":
+		style.FG = lipgloss.Color(color)
+	}
+	c.f.Text(y, x, text, style)
+}
@@ -0,0 +1,55 @@
+package game
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/ashX04/gobowarrow/extension"
+	"github.com/ashX04/gobowarrow/internal/entities"
+)
+
+// crate is a plugin entity that stops arrows and is done with once hit
+type crate struct {
+	x, y int
+	hit  bool
+}
+
+func (c *crate) Update(extension.World, float64) bool { return !c.hit }
+func (c *crate) Render(extension.Canvas)              {}
+func (c *crate) Collide(x, y int) (extension.Hit, bool) {
+	if x != c.x || y != c.y {
+		return extension.Hit{}, false
+	}
+	c.hit = true
+	return extension.Hit{Points: 1, Stop: true, Remove: true}, true
+}
+
+func TestRunsWithPluginsArentSaved(t *testing.T) {
+	g := headless(t, "classic", 1)
+	g.savePath = "save.json"
+	if !g.canSave() {
+		t.Fatal("a plain run can't be saved")
+	}
+	g = g.WithExtensions([]extension.Kind{{Name: "crate"}})
+	if g.canSave() {
+		t.Error("a run with plugin entities can be saved, and would lose them")
+	}
+}
+
+func TestExtrasLeaveCopiesAlone(t *testing.T) {
+	g := headless(t, "classic", 1)
+	crates := []extension.Entity{&crate{x: 1, y: 1}, &crate{x: 2, y: 2}, &crate{x: 3, y: 3}}
+	g.extras = slices.Clone(crates)
+	before := g
+
+	a := entities.Arrow{Active: true}
+	g.hitExtras(&a, 2, 2)
+	g.tickExtras(g.dt())
+
+	if len(g.extras) != 2 {
+		t.Errorf("%d extras left, want 2", len(g.extras))
+	}
+	if !slices.Equal(before.extras, crates) {
+		t.Error("taking an extra off the board changed a copy of the game's")
+	}
+}
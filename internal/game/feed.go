@@ -0,0 +1,78 @@
+package game
+
+import (
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// Event feed sizing
+const (
+	feedSize  = 32 // events kept; the panel shows as many as fit
+	feedWidth = 24 // columns of text in the panel beside the board
+	feedRows  = 3  // events shown above or below the board
+)
+
+// eventLog is a ring buffer of the latest events. Once it's full each new
+// event overwrites the oldest.
+type eventLog struct {
+	lines [feedSize]string
+	next  int // slot the next event goes in
+	count int
+}
+
+// add records an event
+func (l *eventLog) add(line string) {
+	l.lines[l.next] = line
+	l.next = (l.next + 1) % feedSize
+	l.count = min(l.count+1, feedSize)
+}
+
+// recent returns up to n of the latest events, oldest first
+func (l eventLog) recent(n int) []string {
+	n = min(n, l.count)
+	out := make([]string, n)
+	for i := range n {
+		out[i] = l.lines[(l.next-n+i+feedSize)%feedSize]
+	}
+	return out
+}
+
+// announce adds a line to the event feed
+func (m *Game) announce(news string) {
+	m.feed.add(news)
+}
+
+// announceLifeLost puts a life lost to an escaped balloon in the feed
+func (m *Game) announceLifeLost(event) {
+	m.announce("Balloon escaped · " + hearts(m.lives, m.maxLives()))
+}
+
+// feedView renders the event feed as a panel, as tall as the board beside
+// it or as wide as the board above or below it. The newest event is at the
+// bottom, so older ones scroll up and off the top. Long events wrap onto
+// another row.
+func (m Game) feedView(side bool) string {
+	width, rows := m.viewWidth(), feedRows
+	if side {
+		// The board's rendering ends in a newline, which gives it an
+		// extra row
+		width, rows = feedWidth, m.height
+	}
+	titleStyle := lipgloss.NewStyle().Foreground(m.theme.Muted).Bold(true)
+	lineStyle := lipgloss.NewStyle().Foreground(m.theme.Chat).Width(width)
+
+	// The title takes the panel's first row
+	var lines []string
+	for _, line := range m.feed.recent(rows) {
+		lines = append(lines, strings.Split(lineStyle.Render(line), "\n")...)
+	}
+	lines = lines[max(len(lines)-rows, 0):]
+	return lipgloss.NewStyle().
+		BorderStyle(lipgloss.RoundedBorder()).
+		BorderForeground(m.skyBorder()).
+		Padding(0, 1).
+		Width(width + 2).
+		Height(rows + 1).
+		Render(lipgloss.JoinVertical(lipgloss.Left, append([]string{titleStyle.Render("Events")}, lines...)...))
+}
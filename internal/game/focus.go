@@ -0,0 +1,66 @@
+package game
+
+import (
+	"fmt"
+	"math"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// countdownSeconds is how long the count back in takes when the terminal
+// gets focus back after pausing itself
+const countdownSeconds = 3
+
+// blur pauses a run in play when the terminal loses focus, so switching to
+// another window doesn't cost balloons
+func (m *Game) blur() {
+	if m.state != playing {
+		return
+	}
+	m.state = paused
+	m.focusPaused = true
+	m.resumeAt = time.Time{}
+}
+
+// focus picks a run paused by blur back up once the terminal has focus
+// again, after a countdown to get the player's hands back on the keys
+func (m *Game) focus(now time.Time) {
+	if !m.focusPaused || m.state != paused {
+		return
+	}
+	m.focusPaused = false
+	m.state = playing
+	m.resumeAt = now.Add(countdownSeconds * time.Second)
+}
+
+// countingDown reports whether the run is waiting out the countdown
+func (m Game) countingDown() bool {
+	return m.state == playing && m.lastFrame.Before(m.resumeAt)
+}
+
+// countdownView is the number the countdown is on
+func (m Game) countdownView() string {
+	left := int(math.Ceil(m.resumeAt.Sub(m.lastFrame).Seconds()))
+	return fmt.Sprintf("  %d  ", min(max(left, 1), countdownSeconds))
+}
+
+// suspend pauses the run and hands the terminal back to the shell, as
+// ctrl+z does elsewhere. Bubble Tea puts the terminal back the way it was
+// first and takes it over again on fg, where the run waits paused.
+func (m Game) suspend() (tea.Model, tea.Cmd) {
+	if m.noSuspend {
+		return m, nil
+	}
+	if m.state == playing {
+		m.state = paused
+	}
+	return m, tea.Suspend
+}
+
+// WithoutSuspend ignores ctrl+z, for a game played over SSH, where
+// suspending would stop the server rather than the player's session
+func (m Game) WithoutSuspend() Game {
+	m.noSuspend = true
+	return m
+}
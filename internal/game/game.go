@@ -0,0 +1,1242 @@
+// Package game is the Balloon Archer engine. Game is a Bubble Tea model that
+// owns the whole run: input, the fixed-timestep simulation and the View.
+package game
+
+import (
+	"fmt"
+	"math/rand"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/ashX04/gobowarrow/extension"
+	"github.com/ashX04/gobowarrow/internal/achievements"
+	"github.com/ashX04/gobowarrow/internal/anim"
+	"github.com/ashX04/gobowarrow/internal/assets"
+	"github.com/ashX04/gobowarrow/internal/config"
+	"github.com/ashX04/gobowarrow/internal/daily"
+	"github.com/ashX04/gobowarrow/internal/difficulty"
+	"github.com/ashX04/gobowarrow/internal/entities"
+	"github.com/ashX04/gobowarrow/internal/leaderboard"
+	"github.com/ashX04/gobowarrow/internal/level"
+	"github.com/ashX04/gobowarrow/internal/particles"
+	"github.com/ashX04/gobowarrow/internal/profile"
+	"github.com/ashX04/gobowarrow/internal/render"
+	"github.com/ashX04/gobowarrow/internal/replay"
+	"github.com/ashX04/gobowarrow/internal/savegame"
+	"github.com/ashX04/gobowarrow/internal/scores"
+	"github.com/ashX04/gobowarrow/internal/script"
+	"github.com/ashX04/gobowarrow/internal/sound"
+	"github.com/ashX04/gobowarrow/internal/spritepack"
+	"github.com/ashX04/gobowarrow/internal/theme"
+)
+
+// Game states
+const (
+	menu = iota
+	playing
+	paused
+	wrappingUp // the run is over, with dialogs over the board to get through
+	gameOver
+	showingScores
+	settings
+	showingLeaderboard
+)
+
+// maxEscaped is how many balloons may float away before a Classic game ends
+const maxEscaped = 10
+
+// maxInitials is the length of the name stored with a high score
+const maxInitials = 3
+
+// Game represents the game state
+type Game struct {
+	width, height  int
+	archer         int // archer's vertical position
+	archerCol      int // archer's column, within the left third of the board
+	movedAt        int // tick the archer last moved on; 0 before they have
+	aim            int // bow tilt in aimStep units; negative aims upward
+	quiver         quiver
+	selected       arrowKind // arrow kind fired by space
+	arrowsLeft     int       // arrows in the quiver before a reload
+	reloadTicks    int       // ticks until the reload finishes; 0 when idle
+	effects        []effect  // active power-up buffs
+	boss           *boss     // nil when no boss is on the board
+	shockwaves     []shockwave
+	popups         []popup // floating score text
+	particles      particles.System
+	bow            anim.Player         // archer's bow animation
+	renderer       render.Renderer     // draws arrows and trails
+	frame          *render.FrameBuffer // reused so unchanged rows aren't rebuilt
+	arrows         []entities.Arrow
+	balloons       []entities.Balloon
+	birds          []entities.Bird
+	extras         []extension.Entity // plugin entities on the board
+	kinds          []extension.Kind   // entities plugins add to the waves
+	broad          *spatialHash       // collision broad phase, rebuilt every tick; nil until the first
+	bus            bus                // what each kind of event is handed to
+	tiles          tileMap            // the level's walls and mirrors
+	score          int
+	shots          int // arrows fired, used for accuracy
+	hits           int // arrows that popped a balloon
+	combo          int // consecutive hits without a miss
+	strays         int // arrows that flew off the right edge without a hit
+	bestCombo      int
+	escaped        int // balloons that reached the top un-popped
+	lives          int // remaining lives in modes that use them
+	state          int
+	timer          int
+	minBalloonX    int // Add this field
+	maxBalloonX    int // Add this field
+	cfg            config.Config
+	playerCfg      config.Config     // the player's own settings, which daily runs override
+	difficulty     difficulty.Preset // consulted every tick
+	rng            *rand.Rand        // game-owned RNG so seeded runs are reproducible
+	source         *countingSource   // rng's source, counting its draws so a saved run can wind it back
+	seed           int64             // replays this run when passed as --seed
+	highScores     scores.Table
+	scoresPath     string           // empty disables saving
+	record         replay.Run       // this run, kept as a ghost if it's the best
+	ghosts         replay.Ghosts    // best runs per mode and seed
+	ghostsPath     string           // empty disables saving
+	ghost          *replay.Run      // best run being raced, if any
+	ghostShot      int              // next of the ghost's shots to loose
+	ghostArrows    []entities.Arrow // the ghost's arrows in flight
+	dailyHistory   daily.History
+	dailyPath      string            // empty disables saving
+	dailyDay       time.Time         // challenge day being played; zero outside daily runs
+	leaderboard    leaderboard.Board // nil keeps scores offline
+	online         onlineTable       // what the leaderboard screen shows
+	submitErr      error             // why the last run didn't reach the leaderboard
+	guest          *guest            // second archer in a networked match, if any
+	chat           *chatSource       // viewers' balloons; nil when chat is off
+	feed           eventLog          // pops, waves, combos, weather news and trick shots
+	weather        string            // the weather right now; empty before the first tick
+	dialog         dialog            // the box over the board taking keys, if one is open
+	term           terminal          // the window's size, once Bubble Tea has said
+	camera         camera            // which part of a level wider than the board is shown
+	typist         typist            // what's been typed toward a balloon's word in typing mode
+	problem        problem           // the sum math mode is asking about
+	card           reportCard        // how math mode's sums have gone this run
+	downfall       downfall          // Hardcore's end playing out
+	targets        []entities.Target // the practice range's bullseyes
+	coins          int               // earned popping balloons, spent in the shop
+	upgrades       upgrades          // bought in the shop between waves
+	profile        *profile.Profile  // lifetime XP and what it has unlocked; nil when none is kept
+	profilePath    string
+	saved          *savegame.Run    // run quit part way through last time, offered from the menu
+	savePath       string           // empty disables saving runs on quit
+	levelled       bool             // this run's XP reached a new level
+	opened         []profile.Unlock // what that level unlocked
+	saveErr        error
+	menuCursor     int // selected main menu entry
+	mode           int // index into modes
+	wave           wave
+	banner         string          // interstitial text shown over the board
+	bannerTicks    int             // ticks left before the banner hides
+	level          *level.Level    // loaded level; nil plays the built-in waves
+	script         *script.Script  // the level's script for this run; nil without one
+	pack           spritepack.Pack // replaces the built-in art where set
+	levelSpawned   int
+	won            bool // the level's win condition was met
+	waveEscaped    int  // escapes during the current wave
+	achievements   *achievements.Engine
+	toasts         []toast        // queued notifications, front one is shown
+	toastTicks     int            // ticks the front toast has been visible
+	sound          sound.Player   // nil keeps the game silent
+	sounds         []sound.Effect // made since the last frame, played with it
+	music          sound.Music    // nil plays no music
+	juice          juice          // screen shake and hit-stop
+	musicOn        bool           // the music was last told to play
+	charging       bool
+	charge         float64 // 0..1 while the bow is drawn
+	lastShootPress time.Time
+	mover          mover       // held up/down key
+	keys           keyMap      // rebindable play controls
+	theme          theme.Theme // colors the screens are drawn in
+	form           settingsForm
+	configFile     config.Config // the config file as loaded, before flags
+	configPath     string        // empty disables saving settings
+	lastFrame      time.Time     // when the previous frame was drawn
+	resumeAt       time.Time     // when the countdown after regaining focus ends
+	focusPaused    bool          // paused by the terminal losing focus, not the player
+	noSuspend      bool          // ctrl+z is ignored
+	debug          *debugStats   // what the debug overlay shows; nil when it's hidden
+	hitboxes       bool          // collision boxes are outlined
+	consoleLog     []string      // the developer console's last commands and replies
+	god            bool          // the run can't be lost
+	cheated        bool          // the console changed the run, so it isn't kept
+	lag            time.Duration // simulation time not yet run
+}
+
+// New creates a game from cfg, showing the title menu
+func New(cfg config.Config) Game {
+	m := newGame(cfg)
+	m.state = menu
+	m.menuCursor = menuStart
+	return m
+}
+
+// WithLevel plays the given level instead of endless waves
+func (m Game) WithLevel(l *level.Level) Game {
+	m.level = l
+	m.resize(m.cfg.Width, m.cfg.Height)
+	return m
+}
+
+// WithSprites draws the game with a sprite pack's balloons, bow and
+// arrows in place of the built-in ones
+func (m Game) WithSprites(pack spritepack.Pack) Game {
+	m.pack = pack
+	return m
+}
+
+// WithSound plays sound effects through p
+func (m Game) WithSound(p sound.Player) Game {
+	m.sound = p
+	return m
+}
+
+// WithMusic plays music during runs, pausing it everywhere else
+func (m Game) WithMusic(music sound.Music) Game {
+	m.music = music
+	return m
+}
+
+// WithScores attaches a high score table. Scores are saved to path when it
+// isn't empty; otherwise they are kept in memory only.
+func (m Game) WithScores(table scores.Table, path string) Game {
+	m.highScores = table
+	m.scoresPath = path
+	return m
+}
+
+// WithAchievements attaches the achievements engine unlocks are recorded in
+func (m Game) WithAchievements(engine *achievements.Engine) Game {
+	m.achievements = engine
+	return m
+}
+
+// WithGhosts attaches the recorded best runs so each run races the ghost
+// of the best one for its mode and seed. Runs that beat it are saved to
+// path when it isn't empty.
+func (m Game) WithGhosts(ghosts replay.Ghosts, path string) Game {
+	m.ghosts = ghosts
+	m.ghostsPath = path
+	return m
+}
+
+// WithDaily attaches the daily challenge history, saved to path when it
+// isn't empty
+func (m Game) WithDaily(history daily.History, path string) Game {
+	m.dailyHistory = history
+	m.dailyPath = path
+	return m
+}
+
+// WithConfigFile saves changes made on the settings screen to the config
+// file at path, whose contents are file. Only the settings the screen edits
+// are written, so flags given for this run aren't saved with them.
+func (m Game) WithConfigFile(path string, file config.Config) Game {
+	m.configPath = path
+	m.configFile = file
+	return m
+}
+
+// WithLeaderboard posts finished runs to board and lists its top scores
+func (m Game) WithLeaderboard(board leaderboard.Board) Game {
+	m.leaderboard = board
+	return m
+}
+
+// Start begins a fresh run straight away, skipping the menu
+func (m Game) Start() Game {
+	return m.restart()
+}
+
+// newGame sets up a run that is ready to play
+func newGame(cfg config.Config) Game {
+	width := cfg.Width
+	seed := cfg.Seed
+	if seed == 0 {
+		seed = time.Now().UnixNano()
+	}
+	preset, ok := difficulty.Lookup(cfg.Difficulty)
+	if !ok {
+		preset, _ = difficulty.Lookup(difficulty.Default)
+	}
+	palette, ok := theme.Lookup(cfg.Theme, cfg.Themes)
+	if !ok {
+		palette, _ = theme.Lookup(theme.Default, nil)
+	}
+	m := Game{
+		width:       width - 2, // Account for padding
+		height:      cfg.Height,
+		archer:      cfg.Height / 2,
+		arrows:      make([]entities.Arrow, 0),
+		balloons:    make([]entities.Balloon, 0),
+		state:       playing,
+		timer:       0,
+		minBalloonX: (width - 2) / 2, // Account for padding
+		maxBalloonX: width - 7,       // Account for padding and balloon width
+		wave:        newWave(1),
+		quiver:      newQuiver(),
+		keys:        newKeyMap(cfg.Keys),
+		theme:       palette,
+		bow:         anim.Play(assets.BowIdle),
+		renderer:    render.New(cfg.Renderer),
+		frame:       render.NewFrameBuffer(width-2, cfg.Height),
+		cfg:         cfg,
+		playerCfg:   cfg,
+		difficulty:  preset,
+		seed:        seed,
+		bus:         newBus(),
+	}
+	m.rng, m.source = newRNG(seed)
+	m.arrowsLeft = m.quiverSize()
+	return m
+}
+
+// Seed is the RNG seed of the current run. A random seed is picked when the
+// config doesn't set one; passing it back as cfg.Seed replays the run.
+func (m Game) Seed() int64 {
+	return m.seed
+}
+
+// restart returns a fresh game that keeps the loaded high scores
+func (m Game) restart() Game {
+	cfg, day := m.playerCfg, time.Time{}
+	if m.level == nil && modes[m.mode].daily {
+		day = daily.Today()
+		cfg = dailyConfig(cfg, day)
+	}
+	fresh := newGame(cfg)
+	fresh.playerCfg = m.playerCfg
+	fresh.dailyDay = day
+	fresh.dailyHistory = m.dailyHistory
+	fresh.dailyPath = m.dailyPath
+	fresh.profile, fresh.profilePath = m.profile, m.profilePath
+	fresh.saved, fresh.savePath = m.saved, m.savePath
+	fresh.noSuspend = m.noSuspend
+	fresh.debug, fresh.hitboxes = m.debug, m.hitboxes
+	fresh.consoleLog = m.consoleLog
+	fresh.leaderboard = m.leaderboard
+	fresh.chat = m.chat
+	fresh.sound = m.sound
+	fresh.music, fresh.musicOn = m.music, m.musicOn
+	fresh.configFile = m.configFile
+	fresh.configPath = m.configPath
+	if m.guest != nil {
+		fresh.guest = fresh.newGuest()
+	}
+	fresh.highScores = m.highScores
+	fresh.scoresPath = m.scoresPath
+	fresh.achievements = m.achievements
+	fresh.ghosts = m.ghosts
+	fresh.ghostsPath = m.ghostsPath
+	if best, ok := fresh.ghosts.Best(m.currentMode().id, fresh.seed); ok {
+		fresh.ghost = &best
+	}
+	fresh.toasts = m.toasts
+	fresh.term = m.term
+	if fresh.achievements != nil {
+		fresh.achievements.StartRun()
+	}
+	fresh.mode = m.mode
+	if fresh.currentMode().math {
+		fresh.nextProblem()
+	}
+	fresh.level = m.level
+	fresh.resize(fresh.cfg.Width, fresh.cfg.Height)
+	fresh.pack = m.pack
+	fresh.kinds = m.kinds
+	fresh.lives = fresh.currentMode().lives
+	switch {
+	case fresh.level != nil:
+		fresh.showBanner(fresh.level.Name)
+	case fresh.currentMode().targets:
+		fresh.showBanner(fresh.currentMode().name)
+	default:
+		fresh.showBanner("Wave 1")
+	}
+	fresh.fitBoard()
+	fresh.loadScript()
+	return fresh
+}
+
+// toMenu returns to the title screen, keeping the chosen options
+func (m Game) toMenu() Game {
+	fresh := m.restart()
+	fresh.state = menu
+	fresh.menuCursor = m.menuCursor
+	return fresh
+}
+
+func (m Game) Init() tea.Cmd {
+	// The tick loop only runs during play
+	if m.state != playing {
+		return m.listenChat()
+	}
+	return tea.Batch(frame(m.cfg), m.listenChat())
+}
+
+// Update handles game logic
+func (m Game) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.BlurMsg:
+		m.blur()
+		return m, nil
+	case tea.FocusMsg:
+		m.focus(time.Now())
+		return m, nil
+	case tea.KeyMsg:
+		// Whatever the terminal says, a key press means the player is here,
+		// so a pause they've since taken over stays put
+		m.focusPaused = false
+		switch msg.String() {
+		case "ctrl+z":
+			return m.suspend()
+		case "f3":
+			m.toggleDebug()
+			return m, nil
+		case "f4":
+			m.hitboxes = !m.hitboxes
+			return m, nil
+		case "~", "`":
+			if !m.dialog.open() && m.hasConsole() {
+				m.openConsole()
+				return m, nil
+			}
+		}
+		if m.dialog.open() {
+			return m.updateDialog(msg)
+		}
+		switch m.state {
+		case menu:
+			return m.updateMenu(msg)
+		case showingScores:
+			return m.updateSubScreen(msg)
+		case settings:
+			return m.updateSettings(msg)
+		case showingLeaderboard:
+			return m.updateLeaderboard(msg)
+		}
+
+		// Typing mode keeps the letter keys for typing, so Esc pauses
+		if m.currentMode().typing {
+			switch {
+			case msg.Type == tea.KeyEsc && m.state == playing:
+				m.state = paused
+				return m, nil
+			case msg.Type == tea.KeyEsc && m.state == paused:
+				m.state = playing
+				return m, nil
+			case m.state == playing && !m.tooSmall() && m.downfall.ticks == 0 && !m.countingDown() && m.typeKey(msg):
+				return m, nil
+			}
+		}
+
+		switch {
+		case key.Matches(msg, m.keys.quit) && m.state != gameOver && !m.currentMode().zen:
+			// Quitting mid-run would lose it, so check first. Zen has
+			// nothing to lose.
+			m.askQuit()
+			return m, nil
+		case msg.String() == "ctrl+c" || key.Matches(msg, m.keys.quit):
+			return m, m.quit()
+		case key.Matches(msg, m.keys.pause) && (m.state == playing || m.state == paused):
+			// Toggle pause; frames keep arriving but the simulation holds
+			if m.state == playing {
+				m.state = paused
+			} else {
+				m.state = playing
+			}
+			return m, nil
+		case msg.String() == "d" && m.state == gameOver:
+			// Cycle the difficulty used for the next round
+			if !m.currentMode().daily {
+				m.difficulty = difficulty.Next(m.difficulty.Name)
+				m.playerCfg.Difficulty = m.difficulty.Name
+			}
+		case msg.String() == "r" && m.state == gameOver:
+			// Restart from the game-over screen
+			return m.startRun()
+		case msg.String() == "m" && m.state == gameOver:
+			// Back to the title screen from the game-over screen
+			return m.toMenu(), nil
+		case msg.String() == "o" && m.state == paused:
+			return m.openSettings(), nil
+		}
+
+		// Ignore gameplay input while paused, while the board can't be seen,
+		// while counting back in or while Hardcore's end plays out
+		if m.state != playing || m.tooSmall() || m.countingDown() || m.downfall.ticks > 0 {
+			return m, nil
+		}
+
+		switch {
+		case key.Matches(msg, m.keys.up):
+			m.pressMove(step{dy: -1}, time.Now())
+		case key.Matches(msg, m.keys.down):
+			m.pressMove(step{dy: 1}, time.Now())
+		case key.Matches(msg, m.keys.left):
+			m.pressMove(step{dx: -1}, time.Now())
+		case key.Matches(msg, m.keys.right):
+			m.pressMove(step{dx: 1}, time.Now())
+		case key.Matches(msg, m.keys.reload):
+			m.startReload()
+		case key.Matches(msg, m.keys.aimUp):
+			m.adjustAim(-1)
+		case key.Matches(msg, m.keys.aimDown):
+			m.adjustAim(1)
+		case key.Matches(msg, m.keys.panLeft):
+			m.pan(-1)
+		case key.Matches(msg, m.keys.panRight):
+			m.pan(1)
+		case key.Matches(msg, m.keys.shoot): // Tap to shoot, hold to charge
+			m.pressShoot(time.Now())
+		case strings.Contains("1234", msg.String()) && len(msg.String()) == 1:
+			m.selectArrow(arrowKind(msg.String()[0] - '1'))
+		}
+
+	case scoresSavedMsg:
+		if msg.err != nil {
+			m.saveErr = msg.err
+		}
+		return m, nil
+
+	case achievementsSavedMsg:
+		if msg.err != nil {
+			m.saveErr = msg.err
+		}
+		return m, nil
+
+	case ghostsSavedMsg:
+		if msg.err != nil {
+			m.saveErr = msg.err
+		}
+		return m, nil
+
+	case dailySavedMsg:
+		if msg.err != nil {
+			m.saveErr = msg.err
+		}
+		return m, nil
+
+	case HangupMsg:
+		return m, m.quit()
+	case runSavedMsg:
+		if msg.err != nil {
+			m.saveErr = msg.err
+		}
+		return m, nil
+	case profileSavedMsg:
+		if msg.err != nil {
+			m.saveErr = msg.err
+		}
+		return m, nil
+
+	case settingsSavedMsg:
+		m.form.saved, m.form.err = msg.err == nil, msg.err
+		return m, nil
+
+	case scoreSubmittedMsg:
+		m.submitErr = msg.err
+		return m, nil
+
+	case leaderboardMsg:
+		// Drop replies for a mode the player has already moved past
+		if msg.mode == m.online.mode {
+			m.online.entries = msg.entries
+			m.online.err = msg.err
+			m.online.loading = false
+		}
+		return m, nil
+
+	case viewerMsg:
+		// Viewers' balloons only float up during play, in modes that take
+		// them
+		if m.state == playing && m.currentMode().viewers {
+			m.balloons = append(m.balloons, m.viewerBalloon(msg.user))
+		}
+		if m.chat != nil {
+			return m, m.nextViewerBalloon()
+		}
+		return m, nil
+
+	case chatErrMsg:
+		m.chat.client.Close()
+		m.chat = nil
+		m.notify("Chat disconnected", m.theme.Danger)
+		return m, nil
+
+	case tea.WindowSizeMsg:
+		m.term = terminal{width: msg.Width, height: msg.Height}
+		m.fitBoard()
+		return m, nil
+
+	case frameMsg:
+		return m.advance(time.Time(msg))
+	}
+
+	return m, nil
+}
+
+// step runs one fixed simulation tick. It returns a command only when the
+// run ends; otherwise the frame loop carries on.
+func (m Game) step(now time.Time) (Game, tea.Cmd) {
+	// Hardcore's end plays out before the run is wrapped up
+	if m.downfall.ticks > 0 {
+		if m.tickDownfall(); m.downfall.ticks > 0 {
+			return m, nil
+		}
+		return m, m.endRun()
+	}
+	dt := m.dt() * m.timeScale()
+	m.timer++
+	m.tickJuice()
+	m.tickToasts()
+	m.tickCharge(now)
+	m.tickMove(now)
+	m.tickReload()
+	m.tickEffects()
+	m.tickShockwaves()
+	m.tickPopups()
+	m.particles.Update(particleGravity)
+	m.tickAnimations()
+	m.recordTick()
+	// The ghost's run had its own power-ups, so its arrows keep real time
+	m.tickGhost(m.dt())
+	m.tickWeather()
+	m.followCamera(m.dt())
+	m.tickTyping()
+	m.tickMath()
+
+	// Update arrows
+	for i := range m.arrows {
+		if m.arrows[i].Active {
+			m.arrows[i].Update(m.cfg.Gravity, dt)
+			m.collideTiles(&m.arrows[i])
+			// Arrows may arc above the board and come back, so only the
+			// right edge and the ground end their flight
+			x, y := m.arrows[i].Cell()
+			if m.arrows[i].Active && (x >= m.width || y >= m.height) {
+				m.arrows[i].Active = false
+				if !m.arrows[i].Hit {
+					m.registerMiss(m.arrows[i])
+					if x >= m.width && !m.arrows[i].Guest {
+						m.strays++
+					}
+				}
+			}
+		}
+	}
+
+	// Update balloons
+	// Accumulate fractional ascent so wave speed-ups apply smoothly
+	riseRate := m.riseSpeed() * dt
+	wobble := m.difficulty.Wobble
+	wind := m.applyWind()
+	for i := range m.balloons {
+		if !m.balloons[i].Popped {
+			// Move upward with slight horizontal wobble, unless time freeze
+			// is holding every balloon still
+			m.balloons[i].Settle()
+			if m.frozen() {
+				continue
+			}
+			m.balloons[i].Y -= riseRate * m.balloons[i].Speed
+			m.balloons[i].X += ((2*m.rng.Float64()-1)*wobble + wind) * dt
+
+			// Keep within bounds
+			m.balloons[i].X = min(max(m.balloons[i].X, float64(m.minBalloonX)), float64(m.maxBalloonX))
+
+			// Remove if it reaches the top. A balloon cut loose was dealt
+			// with, so it doesn't count as escaping.
+			if m.balloons[i].Y < 0 {
+				m.balloons[i].Popped = true
+				if m.balloons[i].Cut {
+					continue
+				}
+				m.escaped++
+				m.waveEscaped++
+				if m.currentMode().lives > 0 {
+					m.lives--
+					m.emit(event{kind: lifeLost})
+				}
+			}
+		}
+	}
+
+	if !m.frozen() {
+		m.updateBoss(dt)
+	}
+	m.tickBirds(dt)
+	m.tickExtras(dt)
+
+	// Check collisions
+	if m.broad == nil {
+		m.broad = &spatialHash{}
+	}
+	m.broad.build(m.balloons, m.width, m.height)
+	for i := range m.arrows {
+		if m.arrows[i].Active {
+			ax, ay := m.arrows[i].Cell()
+			for _, j := range m.broad.near(ax, ay) {
+				if !m.arrows[i].Active || m.balloons[j].Popped {
+					continue
+				}
+				switch struck(m.balloons[j], ax, ay) {
+				case hitBody:
+					m.hitBalloon(&m.arrows[i], j)
+				case hitString:
+					m.cutString(j)
+				}
+			}
+			if m.arrows[i].Active {
+				m.deflectOffBirds(&m.arrows[i], ax, ay)
+			}
+			m.hitExtras(&m.arrows[i], ax, ay)
+			if m.arrows[i].Active && m.boss != nil && m.boss.contains(ax, ay) {
+				m.hitBoss(&m.arrows[i])
+			}
+			if m.arrows[i].Active {
+				m.hitTargets(&m.arrows[i])
+			}
+		}
+	}
+
+	m.settleJuice()
+
+	// Clean up inactive elements
+	m.arrows = entities.Live(m.arrows)
+	m.balloons = entities.Live(m.balloons)
+
+	m.tickScript()
+
+	// End the game once the mode's limit is reached
+	m.won = m.levelWon()
+	if m.runOver() {
+		return m, m.endRun()
+	}
+
+	// Levels drive their own spawning
+	if m.level != nil {
+		if m.bannerTicks > 0 {
+			m.bannerTicks--
+		} else if !m.frozen() {
+			m.spawnLevelBalloons()
+		}
+		return m, nil
+	}
+
+	// The practice range has nothing coming
+	if m.currentMode().targets {
+		return m, nil
+	}
+
+	// Move on to the next wave once this one is cleared
+	m.advanceWave()
+
+	// Hold spawns while an interstitial banner is showing
+	if m.bannerTicks > 0 {
+		m.bannerTicks--
+		return m, nil
+	}
+
+	// Spawn here rather than in a command so the RNG is only used
+	// from Update and seeded runs replay identically. Nothing new comes
+	// up while time is frozen.
+	if m.frozen() {
+		return m, nil
+	}
+	if balloon, ok := m.spawnBalloon(); ok {
+		m.balloons = append(m.balloons, m.maybePowerUp(balloon))
+		m.wave.spawned++
+	}
+	m.spawnBird()
+	m.spawnExtras()
+
+	return m, nil
+}
+
+// endRun finishes the run and saves what it earned. Hardcore's downfall
+// plays first, and the run ends for real once it has.
+func (m *Game) endRun() tea.Cmd {
+	if m.currentMode().oneMiss && m.downfall.cause == "" {
+		m.startDownfall()
+		return nil
+	}
+	m.state = wrappingUp
+	m.playSound(sound.GameOver)
+	m.wrapUp()
+	m.finishRecord()
+	if m.cheated {
+		return saveAchievements(m.achievements)
+	}
+	return tea.Batch(
+		saveAchievements(m.achievements),
+		m.saveGhost(),
+		m.recordDaily(true),
+		m.submitScore(),
+		m.earnXP(),
+	)
+}
+
+// riseSpeed is how many rows per second of play an ordinary balloon climbs
+// right now. Each balloon scales it by its own speed.
+func (m Game) riseSpeed() float64 {
+	if m.frozen() {
+		return 0
+	}
+	speed := m.difficulty.RiseSpeed * m.wave.speed
+	if pace := m.currentMode().pace; pace > 0 {
+		speed *= pace
+	}
+	return speed
+}
+
+type scoresSavedMsg struct{ err error }
+
+// saveScores writes the table to disk off the Update goroutine
+func saveScores(path string, table scores.Table) tea.Cmd {
+	if path == "" {
+		return nil
+	}
+	return func() tea.Msg {
+		return scoresSavedMsg{err: table.Save(path)}
+	}
+}
+
+// View renders the game, in plain ASCII if the font can't show its
+// symbols. A screen too big for the terminal would wrap into a mess, so it
+// asks for more room instead.
+func (m Game) View() string {
+	if m.debug != nil {
+		defer m.debug.timeRender(time.Now())
+	}
+	view := m.view()
+	if width, height := lipgloss.Size(view); !m.term.fits(width, height) {
+		view = m.enlargeView(width, height)
+	}
+	if m.cfg.ASCII {
+		return render.ASCII(view)
+	}
+	return view
+}
+
+// view renders the current screen
+func (m Game) view() string {
+	switch m.state {
+	case menu:
+		return m.menuView()
+	case showingScores:
+		return m.scoresView()
+	case showingLeaderboard:
+		return m.leaderboardView()
+	case settings:
+		return m.settingsView()
+	case gameOver:
+		return m.gameOverView()
+	}
+
+	return m.playScreen(m.boardView())
+}
+
+// playScreen frames the board and its HUD with the title and controls
+func (m Game) playScreen(board string) string {
+	// Create title style
+	titleStyle := lipgloss.NewStyle().
+		Foreground(m.theme.Title).
+		Bold(true).
+		MarginBottom(1)
+
+	// Create controls style
+	controlsStyle := lipgloss.NewStyle().
+		Foreground(m.theme.Muted).
+		MarginTop(1)
+
+	return lipgloss.JoinVertical(
+		lipgloss.Center,
+		titleStyle.Render("🎯 Balloon Archer 🎈"),
+		board,
+		controlsStyle.Render(m.controlsHelp()),
+	)
+}
+
+// boardView renders the board and the HUD around it: everything on the
+// play screen but the title and controls
+func (m Game) boardView() string {
+	// Create game board
+	board := m.frame
+	board.Clear()
+
+	// Dim everything behind the pause overlay or a dialog
+	isPaused := m.state != playing || m.dialog.open()
+
+	// The scenery and weather sit behind everything. The scenery stays
+	// put while the camera pans across the rest.
+	board.Scroll(0)
+	m.drawScenery(board)
+	board.Scroll(m.cameraX())
+	m.drawWeather(board)
+	m.drawTiles(board, isPaused)
+	m.drawTargets(board, isPaused)
+
+	// The ghost goes down first so the live game draws over it
+	if !isPaused {
+		m.drawGhost(board)
+	}
+
+	// The guest draws first so the local archer wins a shared row
+	m.drawGuest(board, isPaused)
+
+	// Draw archer, in its bow skin if it has one
+	skin, skinned := m.skin()
+	archerStyle := render.Style{FG: m.theme.Accent}
+	if skinned {
+		archerStyle.FG = skin.archer
+	}
+	if isPaused {
+		archerStyle = m.dimStyle()
+	}
+	m.drawArcher(board, archerStyle)
+
+	// Draw arrows
+	arrowStyle := render.Style{}
+	if skinned {
+		arrowStyle.FG = skin.arrow
+	}
+	if isPaused {
+		arrowStyle = m.dimStyle()
+	}
+	alpha := m.alpha()
+	for _, arrow := range m.arrows {
+		if arrow.Active {
+			style := arrowStyle
+			if arrow.Guest && !isPaused {
+				style = m.guestStyle()
+			}
+			m.renderer.DrawArrow(board, arrow.Drawn(alpha), style)
+		}
+	}
+
+	// Draw balloons
+	for _, balloon := range m.balloons {
+		if balloon.Popped {
+			m.drawExplosion(board, balloon, isPaused)
+		} else {
+			balloonStyle := render.Style{FG: balloon.Color}
+			if balloon.Golden {
+				balloonStyle = render.Style{FG: m.shimmer(), Bold: true}
+			}
+			// Draw each line of the balloon at its bobbing offset
+			frame := balloon.Anim.Frame()
+			x, y := balloon.DrawnCell(alpha)
+			x, y = x+frame.DX, y+frame.DY
+			if isPaused || m.fogged(x) {
+				balloonStyle = m.dimStyle()
+			}
+			art := balloon.Art
+			if balloon.Cut {
+				art = art[:balloon.BodyHeight()]
+			}
+			for i, line := range art {
+				board.Text(y+i, x, line, balloonStyle)
+			}
+			// Without colors, markers are all that tell balloons apart
+			if m.cfg.Accessible || m.cfg.Monochrome {
+				drawMarker(board, balloon, x, y, balloonStyle)
+			}
+			if balloon.Word != "" {
+				m.drawWord(board, balloon, x, y, isPaused || m.fogged(x))
+			}
+			if balloon.Label != "" {
+				style := m.labelStyle()
+				if isPaused || m.fogged(x) {
+					style = m.dimStyle()
+				}
+				drawLabel(board, balloon, x, y, style)
+			}
+		}
+	}
+
+	m.drawBirds(board, isPaused)
+	m.drawExtras(board, isPaused)
+	m.drawBoss(board, isPaused)
+	m.drawShockwaves(board)
+	m.drawParticles(board)
+	m.drawPopups(board)
+	m.drawHitboxes(board)
+
+	// Draw aim preview and charge meter beside the archer. Typing mode
+	// aims by itself, so it has no preview.
+	if !isPaused && !m.currentMode().typing && m.downfall.ticks == 0 {
+		m.drawTrajectory(board)
+	}
+	m.drawChargeMeter(board)
+
+	// Draw pause overlay across the middle of the board
+	board.Scroll(0)
+	m.drawProblem(board)
+	if m.dialog.open() {
+		m.drawDialog(board)
+	} else if m.state == paused {
+		m.drawOverlay(board, fmt.Sprintf("  PAUSED — %s resume, o settings  ", label(m.keys.pause)))
+	} else if m.countingDown() {
+		m.drawOverlay(board, m.countdownView())
+	} else if m.downfall.ticks > 0 {
+		m.drawDownfall(board)
+	} else if m.bannerTicks > 0 {
+		m.drawOverlay(board, "  "+m.banner+"  ")
+	}
+	m.drawToast(board)
+	m.drawDebug(board)
+
+	// Render board with border
+	gameArea := board.Render()
+
+	// Create border styles
+	borderStyle := lipgloss.NewStyle().
+		BorderStyle(lipgloss.RoundedBorder()).
+		BorderForeground(m.borderColor()).
+		Padding(0, 1).            // Add some padding
+		Width(m.viewWidth() + 2). // Account for padding
+		Align(lipgloss.Center)
+
+	area := m.shaken(borderStyle).Render(gameArea)
+	if hp := m.bossHPView(); hp != "" {
+		area = lipgloss.JoinVertical(lipgloss.Center, hp, area)
+	}
+	return m.layoutHUD(area)
+}
+
+// packLines joins items with sep, starting a new line whenever the next
+// item would make the line wider than width
+func packLines(items []string, sep string, width int) string {
+	var lines []string
+	line := ""
+	for _, item := range items {
+		switch {
+		case line == "":
+			line = item
+		case lipgloss.Width(line+sep+item) > width:
+			lines = append(lines, line)
+			line = item
+		default:
+			line += sep + item
+		}
+	}
+	return strings.Join(append(lines, line), "\n")
+}
+
+// gameOverView renders the final score screen
+func (m Game) gameOverView() string {
+	titleStyle := lipgloss.NewStyle().
+		Foreground(m.theme.Danger).
+		Bold(true).
+		MarginBottom(1)
+
+	statsStyle := lipgloss.NewStyle().
+		Foreground(m.theme.Score)
+
+	boxStyle := lipgloss.NewStyle().
+		BorderStyle(lipgloss.DoubleBorder()).
+		BorderForeground(m.theme.Border).
+		Padding(1, 4).
+		Align(lipgloss.Center)
+
+	controlsStyle := lipgloss.NewStyle().
+		Foreground(m.theme.Muted).
+		MarginTop(1)
+
+	lines := []string{
+		"Mode: " + m.currentMode().name,
+		fmt.Sprintf("Final score: %d", m.score),
+	}
+	if m.guest != nil {
+		lines = append(lines, fmt.Sprintf("Player 2 score: %d", m.guest.score))
+	}
+	if m.level == nil {
+		lines = append(lines, fmt.Sprintf("Wave reached: %d", m.wave.number))
+	}
+	lines = append(lines,
+		fmt.Sprintf("Arrows fired: %d", m.shots),
+		fmt.Sprintf("Accuracy: %.0f%%", m.accuracy()),
+		fmt.Sprintf("Best combo: %d", m.bestCombo),
+		fmt.Sprintf("Seed: %d", m.seed),
+	)
+	if m.currentMode().math {
+		lines = append(lines, m.reportCardView()...)
+	}
+	if m.achievements != nil {
+		lines = append(lines, fmt.Sprintf("Achievements: %d/%d", m.achievements.Count(), len(achievements.All)))
+	}
+	if m.profile != nil && !m.cheated {
+		lines = append(lines, m.progressView()...)
+	}
+	if m.cheated {
+		lines = append(lines, "Console used: this run isn't kept")
+	}
+	stats := strings.Join(lines, "\n")
+
+	footer := lipgloss.JoinVertical(
+		lipgloss.Center,
+		m.highScoreTable(m.highScores.ForMode(m.currentMode().id)),
+		controlsStyle.Render(fmt.Sprintf(
+			"Difficulty: %s (d to change)\nr to play again, m for menu, q to quit",
+			m.difficulty.Name,
+		)),
+	)
+	if m.currentMode().daily {
+		footer = lipgloss.JoinVertical(
+			lipgloss.Center,
+			m.dailyCalendar(m.dailyHistory, daily.Today()),
+			controlsStyle.Render("Come back tomorrow for a new challenge!\nm for menu, q to quit"),
+		)
+	}
+	if m.saveErr != nil {
+		errStyle := lipgloss.NewStyle().Foreground(m.theme.Danger)
+		footer = lipgloss.JoinVertical(
+			lipgloss.Center,
+			footer,
+			errStyle.Render("Could not save: "+m.saveErr.Error()),
+		)
+	}
+	// Being offline only costs the online entry, so keep it quiet
+	if m.submitErr != nil {
+		footer = lipgloss.JoinVertical(
+			lipgloss.Center,
+			footer,
+			controlsStyle.Render("Leaderboard offline; score kept locally"),
+		)
+	}
+
+	title := "💥 GAME OVER 💥"
+	if m.won {
+		title = "🏆 LEVEL COMPLETE 🏆"
+		titleStyle = titleStyle.Foreground(m.theme.Success)
+	}
+
+	content := lipgloss.JoinVertical(
+		lipgloss.Center,
+		titleStyle.Render(title),
+		statsStyle.Render(stats),
+		footer,
+	)
+
+	return lipgloss.Place(
+		m.viewWidth()+4, m.height+6,
+		lipgloss.Center, lipgloss.Center,
+		boxStyle.Render(content),
+	)
+}
+
+// highScoreTable renders the top scores as aligned rows
+func (m Game) highScoreTable(table scores.Table) string {
+	headerStyle := lipgloss.NewStyle().
+		Foreground(m.theme.Title).
+		Bold(true).
+		MarginTop(1)
+	rowStyle := lipgloss.NewStyle().Foreground(m.theme.Text)
+
+	if len(table) == 0 {
+		return headerStyle.Render("No high scores yet")
+	}
+
+	rows := []string{headerStyle.Render("HIGH SCORES")}
+	for i, e := range table {
+		rows = append(rows, rowStyle.Render(fmt.Sprintf(
+			"%2d. %-3s %5d  %s",
+			i+1, e.Initials, e.Score, e.Time.Format("2006-01-02"),
+		)))
+	}
+	return lipgloss.JoinVertical(lipgloss.Left, rows...)
+}
+
+// accuracy returns the percentage of fired arrows that hit a balloon
+func (m Game) accuracy() float64 {
+	if m.shots == 0 {
+		return 0
+	}
+	return float64(m.hits) / float64(m.shots) * 100
+}
+
+// drawOverlay writes a highlighted banner into the center row of the board
+func (m Game) drawOverlay(board *render.FrameBuffer, text string) {
+	overlayStyle := render.Style{FG: m.theme.Contrast, BG: m.theme.Border, Bold: true}
+	board.Text(board.Height()/2, max((board.Width()-lipgloss.Width(text))/2, 0), text, overlayStyle)
+}
+
+// drawMarker writes b's type symbol into the middle of its art, so
+// balloons can be told apart without relying on color
+func drawMarker(f *render.FrameBuffer, b entities.Balloon, x, y int, style render.Style) {
+	if b.Marker == "" {
+		return
+	}
+	row := (len(b.Art) - 1) / 2
+	col := (lipgloss.Width(b.Art[row]) - lipgloss.Width(b.Marker)) / 2
+	style.Bold = true
+	f.Text(y+row, x+col, b.Marker, style)
+}
+
+// marker picks the symbol a balloon is marked with. Monochrome and ASCII
+// terminals get the plain letter, since they may lack the fonts for more.
+func (m Game) marker(symbol, letter string) string {
+	if m.cfg.Monochrome || m.cfg.ASCII {
+		return letter
+	}
+	return symbol
+}
+
+// dimStyle is how everything behind the pause overlay is drawn
+func (m Game) dimStyle() render.Style {
+	return render.Style{FG: m.theme.Faint, Faint: true}
+}
+
+// spawnBalloon rolls for the wave's next balloon at the bottom of the board
+func (m Game) spawnBalloon() (entities.Balloon, bool) {
+	if m.wave.exhausted() {
+		return entities.Balloon{}, false
+	}
+	if m.rng.Float64() >= m.difficulty.SpawnChance(m.cfg.SpawnChance*m.wave.density) {
+		return entities.Balloon{}, false
+	}
+
+	if m.rng.Float64() < goldenChance {
+		return m.newGoldenBalloon(), true
+	}
+	sprites := m.balloonSprites()
+	selected := sprites[m.rng.Intn(len(sprites))]
+	return m.newSizedBalloon(selected, m.pickSize()), true
+}
+
+// newBalloon places art at a random spot along the bottom of the board
+func (m Game) newBalloon(art []string, color lipgloss.Color) entities.Balloon {
+	// Calculate balloon dimensions
+	width := render.ArtWidth(art)
+	height := len(art)
+
+	screenWidth := m.width + 2 // The whole world, padding included
+	minX := screenWidth / 2
+	maxX := screenWidth - width - 2
+	spawnX := minX + m.rng.Intn(maxX-minX)
+
+	// Start each balloon at a different point in its bob
+	bob := anim.Play(balloonBob)
+	bob.Skip(m.rng.Intn(balloonBob.Duration()))
+
+	return entities.Balloon{
+		Position: entities.At(float64(spawnX), float64(m.height-1)),
+		Art:      art,
+		Color:    color,
+		Width:    width,
+		Height:   height,
+		Points:   1,
+		Speed:    1,
+		Anim:     bob,
+	}
+}
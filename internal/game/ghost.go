@@ -0,0 +1,110 @@
+package game
+
+import (
+	"fmt"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/ashX04/gobowarrow/internal/entities"
+	"github.com/ashX04/gobowarrow/internal/physics"
+	"github.com/ashX04/gobowarrow/internal/render"
+	"github.com/ashX04/gobowarrow/internal/replay"
+)
+
+// ghostStyle is how the replay of the best run is drawn, behind everything
+func (m Game) ghostStyle() render.Style {
+	return render.Style{FG: m.theme.Faint, Faint: true}
+}
+
+// ghostsSavedMsg reports the result of writing the ghosts file
+type ghostsSavedMsg struct{ err error }
+
+// recordTick notes where the archer stood this tick
+func (m *Game) recordTick() {
+	m.record.Archer = append(m.record.Archer, m.archer)
+	m.record.Columns = append(m.record.Columns, m.archerCol)
+}
+
+// recordShot notes an arrow leaving the bow
+func (m *Game) recordShot(a entities.Arrow) {
+	m.record.Shots = append(m.record.Shots, replay.Shot{
+		Tick:   m.timer,
+		X:      a.Body.Pos.X,
+		Y:      a.Body.Pos.Y,
+		VX:     a.Body.Vel.X,
+		VY:     a.Body.Vel.Y,
+		Symbol: a.Symbol,
+	})
+}
+
+// tickGhost looses the ghost's shots that are due and moves its arrows.
+// Ghost arrows fly like real ones but never hit anything.
+func (m *Game) tickGhost(dt float64) {
+	if m.ghost == nil {
+		return
+	}
+	for ; m.ghostShot < len(m.ghost.Shots); m.ghostShot++ {
+		s := m.ghost.Shots[m.ghostShot]
+		if s.Tick > m.timer {
+			break
+		}
+		pos := physics.Vec{X: s.X, Y: s.Y}
+		m.ghostArrows = append(m.ghostArrows, entities.Arrow{
+			Body:   physics.Body{Pos: pos, Vel: physics.Vec{X: s.VX, Y: s.VY}},
+			Active: true,
+			Symbol: s.Symbol,
+			Prev:   pos,
+		})
+	}
+
+	arrows := m.ghostArrows[:0]
+	for _, a := range m.ghostArrows {
+		a.Update(m.cfg.Gravity, dt)
+		if x, y := a.Cell(); x < m.width && y < m.height {
+			arrows = append(arrows, a)
+		}
+	}
+	m.ghostArrows = arrows
+}
+
+// drawGhost draws the best run's archer and arrows where they were at
+// this point in that run
+func (m Game) drawGhost(f *render.FrameBuffer) {
+	if m.ghost == nil {
+		return
+	}
+	if col, row, ok := m.ghost.At(m.timer - 1); ok {
+		f.Set(col, row, "|)", m.ghostStyle())
+	}
+	alpha := m.alpha()
+	for _, a := range m.ghostArrows {
+		m.renderer.DrawArrow(f, a.Drawn(alpha), m.ghostStyle())
+	}
+}
+
+// finishRecord stamps the recording with how the run ended
+func (m *Game) finishRecord() {
+	m.record.Mode = m.currentMode().id
+	m.record.Seed = m.seed
+	m.record.Score = m.score
+}
+
+// saveGhost keeps the finished run as the ghost to race if it's the best
+// for its mode and seed
+func (m *Game) saveGhost() tea.Cmd {
+	ghosts, best := m.ghosts.Record(m.record)
+	if !best {
+		return nil
+	}
+	m.ghosts = ghosts
+	if m.ghostsPath == "" {
+		return nil
+	}
+	path := m.ghostsPath
+	return func() tea.Msg {
+		if err := ghosts.Save(path); err != nil {
+			return ghostsSavedMsg{err: fmt.Errorf("ghosts: %w", err)}
+		}
+		return ghostsSavedMsg{}
+	}
+}
@@ -0,0 +1,87 @@
+package game
+
+import (
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/ashX04/gobowarrow/internal/anim"
+	"github.com/ashX04/gobowarrow/internal/assets"
+	"github.com/ashX04/gobowarrow/internal/render"
+	"github.com/ashX04/gobowarrow/internal/sound"
+	"github.com/ashX04/gobowarrow/internal/theme"
+)
+
+// Hardcore's downfall tuning
+const (
+	downfallSeconds = 2 // how long the end plays out before the game over dialogs
+	downfallBurst   = 3 // ticks between the balloons left on the board bursting
+	downfallFlash   = 2 // ticks the border spends on each color as it flashes
+)
+
+// downfall is how a Hardcore run ends: everything stops, the board flashes
+// red and shakes, and the balloons still up burst one after another. The
+// bursts score nothing.
+type downfall struct {
+	ticks int    // left to play out; 0 when it isn't
+	cause string // what ended the run; set once it has started
+}
+
+// startDownfall freezes the run where the one miss happened and starts its
+// end playing out
+func (m *Game) startDownfall() {
+	m.downfall = downfall{
+		ticks: downfallSeconds * m.cfg.TickRate,
+		cause: "A balloon got away",
+	}
+	if m.strays > 0 {
+		m.downfall.cause = "An arrow missed"
+	}
+	m.charging = false
+	m.playSound(sound.BossHit)
+	m.shakeScreen()
+}
+
+// tickDownfall plays one tick of the downfall. Only the effects move: the
+// arrows and balloons hang where they were.
+func (m *Game) tickDownfall() {
+	m.downfall.ticks--
+	m.tickJuice()
+	m.tickPopups()
+	m.particles.Update(particleGravity)
+	m.tickAnimations()
+	if m.downfall.ticks%downfallBurst != 0 {
+		return
+	}
+	for i := range m.balloons {
+		b := &m.balloons[i]
+		if b.Popped {
+			continue
+		}
+		b.Popped = true
+		x, y := b.Center()
+		m.explode(x, y, b.Color)
+		b.Anim = anim.Play(assets.Explosion)
+		b.Despawn = despawnTicks
+		m.playSound(sound.Pop)
+		m.shakeScreen()
+		break
+	}
+}
+
+// drawDownfall writes what ended the run across the board
+func (m Game) drawDownfall(board *render.FrameBuffer) {
+	text := "  💀 " + m.downfall.cause + " 💀  "
+	style := render.Style{FG: m.theme.Contrast, BG: m.theme.Danger, Bold: true}
+	board.Text(board.Height()/2, max((board.Width()-lipgloss.Width(text))/2, 0), text, style)
+}
+
+// borderColor is the board border's color: the sky's, except during a
+// downfall, when it flashes red. With reduced motion it stays red instead.
+func (m Game) borderColor() theme.Color {
+	switch {
+	case m.downfall.ticks == 0:
+		return m.skyBorder()
+	case m.cfg.ReducedMotion || m.downfall.ticks/downfallFlash%2 == 0:
+		return m.theme.Danger
+	}
+	return m.skyBorder()
+}
@@ -0,0 +1,54 @@
+package game
+
+import "time"
+
+// Stats summarises a run, for headless output
+type Stats struct {
+	Seed      int64   `json:"seed"`
+	Ticks     int     `json:"ticks"`
+	Score     int     `json:"score"`
+	Wave      int     `json:"wave"`
+	Escaped   int     `json:"escaped"`
+	Shots     int     `json:"shots"`
+	Hits      int     `json:"hits"`
+	Accuracy  float64 `json:"accuracy"` // percent of shots that hit
+	BestCombo int     `json:"best_combo"`
+	Balloons  int     `json:"balloons"` // still on the board at the end
+	Over      bool    `json:"game_over"`
+	Won       bool    `json:"won"`
+}
+
+// Simulate runs up to ticks simulation steps without a terminal, stopping
+// early if the run ends. Time advances by exactly one tick per step, so a
+// seeded game always plays out the same way.
+func (m Game) Simulate(ticks int) Game {
+	now := time.Unix(0, 0)
+	for range ticks {
+		if m.state != playing {
+			break
+		}
+		now = now.Add(m.cfg.TickInterval())
+		// Commands only save scores and achievements, which headless
+		// runs don't load
+		m, _ = m.step(now)
+	}
+	return m
+}
+
+// Stats reports how the run has gone so far
+func (m Game) Stats() Stats {
+	return Stats{
+		Seed:      m.seed,
+		Ticks:     m.timer,
+		Score:     m.score,
+		Wave:      m.wave.number,
+		Escaped:   m.escaped,
+		Shots:     m.shots,
+		Hits:      m.hits,
+		Accuracy:  m.accuracy(),
+		BestCombo: m.bestCombo,
+		Balloons:  len(m.balloons),
+		Over:      m.state == gameOver || m.state == wrappingUp,
+		Won:       m.won,
+	}
+}
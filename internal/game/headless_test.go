@@ -0,0 +1,62 @@
+package game
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/ashX04/gobowarrow/internal/config"
+)
+
+// headless sets up a run of mode, by id, ready to simulate
+func headless(tb testing.TB, mode string, seed int64) Game {
+	tb.Helper()
+	cfg := config.Default()
+	cfg.Seed = seed
+	g := New(cfg)
+	g.mode = slices.IndexFunc(modes, func(m gameMode) bool { return m.id == mode })
+	if g.mode < 0 {
+		tb.Fatalf("no mode %q", mode)
+	}
+	return g.Start()
+}
+
+// TestSimulateGolden pins down how seeded runs play out. A change to any
+// of these means the simulation no longer replays old seeds the same, so
+// only update them when that's intended.
+func TestSimulateGolden(t *testing.T) {
+	for _, tt := range []struct {
+		mode string
+		seed int64
+		want Stats
+	}{
+		{"classic", 42, Stats{Seed: 42, Ticks: 164, Wave: 2, Escaped: 10, Balloons: 1, Over: true}},
+		{"survival", 7, Stats{Seed: 7, Ticks: 71, Wave: 1, Escaped: 3, Balloons: 2, Over: true}},
+		{"time-attack", 1, Stats{Seed: 1, Ticks: 600, Wave: 3, Escaped: 44, Balloons: 1, Over: true}},
+		{"zen", 3, Stats{Seed: 3, Ticks: 3000, Wave: 17, Escaped: 652, Balloons: 5}},
+	} {
+		t.Run(tt.mode, func(t *testing.T) {
+			got := headless(t, tt.mode, tt.seed).Simulate(3000).Stats()
+			if got != tt.want {
+				t.Errorf("Stats() =\n%+v\nwant\n%+v", got, tt.want)
+			}
+			// And again, to be sure nothing outside the run leaks in
+			if again := headless(t, tt.mode, tt.seed).Simulate(3000).Stats(); again != got {
+				t.Errorf("second run Stats() =\n%+v\nwant\n%+v", again, got)
+			}
+		})
+	}
+}
+
+// BenchmarkSimulate times the game loop alone. Zen runs never end, so
+// every iteration simulates the full 1000 ticks. Each starts from a fresh
+// game, since copies of one share its RNG and would each play on from
+// where the last left off.
+func BenchmarkSimulate(b *testing.B) {
+	b.ReportAllocs()
+	for range b.N {
+		b.StopTimer()
+		g := headless(b, "zen", 3)
+		b.StartTimer()
+		g.Simulate(1000)
+	}
+}
@@ -0,0 +1,64 @@
+package game
+
+import (
+	"github.com/ashX04/gobowarrow/internal/render"
+)
+
+// drawHitboxes outlines what collision checks arrows and balloons against,
+// for tuning near misses. Boxes are drawn around the cells that count, at
+// the cells of the last tick rather than where things are drawn between
+// ticks or bobbing. An arrow hits a balloon where their boxes overlap: a
+// balloon's body in one color and its string in another.
+func (m Game) drawHitboxes(f *render.FrameBuffer) {
+	if !m.hitboxes {
+		return
+	}
+	body := render.Style{FG: m.theme.Success}
+	str := render.Style{FG: m.theme.Accent}
+	for _, b := range m.balloons {
+		if b.Popped {
+			continue
+		}
+		x, y := b.Cell()
+		// The same rows struck counts as body and string
+		split, bottom := y+b.BodyHeight(), y+b.Height
+		if b.StringRows() == 0 {
+			split = bottom + 1
+		} else if b.Cut {
+			bottom = split - 1
+		}
+		left, right := x-1, x+b.Width+1
+		f.Set(left, y-1, "┌", body)
+		f.Set(right, y-1, "┐", body)
+		for col := x; col < right; col++ {
+			f.Set(col, y-1, "─", body)
+		}
+		for row := y; row <= bottom; row++ {
+			style := body
+			if row >= split {
+				style = str
+			}
+			f.Set(left, row, "│", style)
+			f.Set(right, row, "│", style)
+		}
+		style := body
+		if bottom >= split {
+			style = str
+		}
+		f.Set(left, bottom+1, "└", style)
+		f.Set(right, bottom+1, "┘", style)
+		for col := x; col < right; col++ {
+			f.Set(col, bottom+1, "─", style)
+		}
+	}
+
+	arrow := render.Style{FG: m.theme.Danger, Bold: true}
+	for _, a := range m.arrows {
+		if !a.Active {
+			continue
+		}
+		x, y := a.Cell()
+		f.Set(x-1, y, "[", arrow)
+		f.Set(x+arrowReach+1, y, "]", arrow)
+	}
+}
@@ -0,0 +1,147 @@
+package game
+
+import (
+	"fmt"
+
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/ashX04/gobowarrow/internal/config"
+)
+
+// sideWidth is how wide a panel beside the board is: as wide as the event
+// feed with its border
+const sideWidth = feedWidth + 4
+
+// Countdown bar widths
+const (
+	timerBar     = 40
+	sideTimerBar = sideWidth - 8
+)
+
+// layoutHUD arranges the HUD panels around the rendered board where the
+// config puts them. Panels sharing a place stack in the config's order.
+func (m Game) layoutHUD(board string) string {
+	views := map[string]func(side bool) string{
+		"quiver": m.quiverPanel,
+		"timer":  m.timerView,
+		"score":  m.scoreView,
+		"combo":  m.comboView,
+		"feed":   m.feedView,
+	}
+	placed := map[string][]string{}
+	hud := m.cfg.HUD
+	for _, p := range hud.Panels() {
+		place := *p.Place
+		if place == config.PanelHidden {
+			continue
+		}
+		side := place == config.PanelLeft || place == config.PanelRight
+		if view := views[p.Name](side); view != "" {
+			placed[place] = append(placed[place], view)
+		}
+	}
+
+	row := []string{board}
+	if left := placed[config.PanelLeft]; len(left) > 0 {
+		row = append([]string{lipgloss.JoinVertical(lipgloss.Left, left...), " "}, row...)
+	}
+	if right := placed[config.PanelRight]; len(right) > 0 {
+		row = append(row, " ", lipgloss.JoinVertical(lipgloss.Left, right...))
+	}
+	column := append(placed[config.PanelTop], lipgloss.JoinHorizontal(lipgloss.Top, row...))
+	column = append(column, placed[config.PanelBottom]...)
+	return lipgloss.JoinVertical(lipgloss.Center, column...)
+}
+
+// quiverPanel shows the quiver with any active power-ups under it
+func (m Game) quiverPanel(side bool) string {
+	lines := []string{m.quiverView(side)}
+	if badges := m.effectsView(side); badges != "" {
+		lines = append(lines, badges)
+	}
+	align := lipgloss.Center
+	if side {
+		align = lipgloss.Left
+	}
+	return lipgloss.JoinVertical(align, lines...)
+}
+
+// timerView is the countdown bar for timed modes
+func (m Game) timerView(side bool) string {
+	mode := m.currentMode()
+	if mode.timeLimit == 0 {
+		return ""
+	}
+	timerStyle := lipgloss.NewStyle().Foreground(m.theme.Accent)
+	left := m.timeLeft()
+	if left <= 10 {
+		timerStyle = timerStyle.Foreground(m.theme.Danger)
+	}
+	width := timerBar
+	if side {
+		width = sideTimerBar
+	}
+	bar := m.meter(width, left/float64(mode.timeLimit), m.theme.Danger, m.theme.Accent)
+	return timerStyle.Render(fmt.Sprintf("⏱ %2.0fs ", left)) + bar
+}
+
+// scoreView is the status line, built from whatever the current mode
+// tracks. Beside the board it wraps to the panel's width.
+func (m Game) scoreView(side bool) string {
+	scoreStyle := lipgloss.NewStyle().
+		Foreground(m.theme.Score).
+		MarginTop(1).
+		Align(lipgloss.Center)
+	width := m.viewWidth() + 4
+	if side {
+		scoreStyle = scoreStyle.UnsetMargins().Align(lipgloss.Left)
+		width = sideWidth
+	}
+
+	mode := m.currentMode()
+	if mode.zen && !m.cfg.ZenStats {
+		return ""
+	}
+	hud := []string{fmt.Sprintf("Score: %d", m.score)}
+	if mode.zen {
+		hud = []string{fmt.Sprintf("Hits: %d", m.hits), fmt.Sprintf("Accuracy: %.0f%%", m.accuracy())}
+	}
+	if m.guest != nil {
+		hud[0] = fmt.Sprintf("P1: %d   P2: %d", m.score, m.guest.score)
+	}
+	switch {
+	case m.level != nil:
+		hud[0] += fmt.Sprintf("/%d", m.level.Win.Score)
+	case mode.targets:
+		hud = append(hud, fmt.Sprintf("Arrows: %d/%d", m.shots, rangeShots))
+	default:
+		hud = append(hud, fmt.Sprintf("Wave: %d", m.wave.number))
+	}
+	if limit := m.escapeLimit(); limit > 0 {
+		hud = append(hud, fmt.Sprintf("Escaped: %d/%d", m.escaped, limit))
+	}
+	if m.hasShop() {
+		hud = append(hud, fmt.Sprintf("Coins: %d", m.coins))
+	}
+	if mode.typing {
+		hud = append(hud, fmt.Sprintf("Typos: %d", m.typist.typos))
+	} else {
+		hud = append(hud, "Aim: "+m.aimLabel())
+	}
+	hud = append(hud, "Difficulty: "+m.difficulty.Name)
+	// Hearts go last since their own color resets the HUD style
+	if mode.lives > 0 {
+		heartStyle := lipgloss.NewStyle().Foreground(m.theme.Danger)
+		hud = append(hud, heartStyle.Render(hearts(m.lives, m.maxLives())))
+	}
+	return scoreStyle.Render(packLines(hud, "   ", width))
+}
+
+// comboView shows the running combo and its multiplier once there is one
+func (m Game) comboView(side bool) string {
+	if m.combo <= 1 {
+		return ""
+	}
+	comboStyle := lipgloss.NewStyle().Foreground(m.theme.Score).Bold(true)
+	return comboStyle.Render(fmt.Sprintf("Combo: %d (x%d)", m.combo, m.multiplier()))
+}
@@ -0,0 +1,58 @@
+package game
+
+import "github.com/charmbracelet/lipgloss"
+
+// Juice tuning
+const (
+	shakeTicks   = 3 // ticks the board shakes after a boss hit
+	hitStopTicks = 1 // ticks the action holds after popping several balloons at once
+)
+
+// juice is the screen shake and hit-stop layered over the action. It only
+// changes how a run looks and feels, never what happens in it, so seeded
+// runs play out the same with reduced motion on.
+type juice struct {
+	shake   int // ticks of shake left
+	hitStop int // ticks the simulation holds for
+	pops    int // balloons popped this tick
+}
+
+// countPop counts a balloon popped this tick, toward the hit stop for
+// popping several at once
+func (m *Game) countPop(event) {
+	m.juice.pops++
+}
+
+// shakeScreen starts the board shaking
+func (m *Game) shakeScreen() {
+	if !m.cfg.ReducedMotion {
+		m.juice.shake = shakeTicks
+	}
+}
+
+// tickJuice winds the shake down at the start of a tick
+func (m *Game) tickJuice() {
+	m.juice.shake = max(m.juice.shake-1, 0)
+	m.juice.pops = 0
+}
+
+// settleJuice holds the action a moment after a multi-pop, once the
+// tick's collisions are done
+func (m *Game) settleJuice() {
+	if m.juice.pops >= 2 && !m.cfg.ReducedMotion {
+		m.juice.hitStop = hitStopTicks
+	}
+}
+
+// shaken nudges the board a cell to one side or the other while it shakes.
+// The board is centered, so a two-cell margin moves it by one.
+func (m Game) shaken(board lipgloss.Style) lipgloss.Style {
+	switch {
+	case m.juice.shake == 0 || m.state != playing:
+		return board
+	case m.juice.shake%2 == 0:
+		return board.MarginLeft(2)
+	default:
+		return board.MarginRight(2)
+	}
+}
@@ -0,0 +1,97 @@
+package game
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/ashX04/gobowarrow/internal/config"
+)
+
+// keyMap holds the rebindable play controls
+type keyMap struct {
+	up, down, left, right, aimUp, aimDown, shoot, reload, pause, quit key.Binding
+	panLeft, panRight                                                 key.Binding
+}
+
+// newKeyMap builds the controls from the config's [keys] table
+func newKeyMap(k config.Keys) keyMap {
+	return keyMap{
+		up:      binding(k.Up),
+		down:    binding(k.Down),
+		left:    binding(k.Left),
+		right:   binding(k.Right),
+		aimUp:   binding(k.AimUp),
+		aimDown: binding(k.AimDown),
+		shoot:   binding(k.Shoot),
+		reload:  binding(k.Reload),
+		pause:   binding(k.Pause),
+		quit:    binding(k.Quit),
+
+		panLeft:  binding(k.PanLeft),
+		panRight: binding(k.PanRight),
+	}
+}
+
+// binding matches names as Bubble Tea reports keys, which spells the
+// space bar " "
+func binding(names []string) key.Binding {
+	keys := make([]string, len(names))
+	for i, name := range names {
+		if name == "space" {
+			name = " "
+		}
+		keys[i] = name
+	}
+	return key.NewBinding(key.WithKeys(keys...), key.WithHelp(keyLabel(names), ""))
+}
+
+// keyLabels are friendlier names for keys in the controls line
+var keyLabels = map[string]string{
+	"up": "↑", "down": "↓", "left": "←", "right": "→", "space": "SPACE", "enter": "ENTER",
+}
+
+// keyLabel names the keys bound to a control for on-screen help
+func keyLabel(names []string) string {
+	labels := make([]string, len(names))
+	for i, name := range names {
+		if l, ok := keyLabels[name]; ok {
+			name = l
+		}
+		labels[i] = name
+	}
+	return strings.Join(labels, "|")
+}
+
+// label is the on-screen name of b's keys
+func label(b key.Binding) string {
+	return b.Help().Key
+}
+
+// controlsHelp is the controls line shown under the board, with the pan
+// keys on levels wider than it
+func (m Game) controlsHelp() string {
+	k := m.keys
+	if m.currentMode().typing {
+		// Every letter goes to typing, so the lettered controls are off
+		return "Controls: type a balloon's word to shoot it, BACKSPACE undo, arrow keys move, ESC pause"
+	}
+	if m.scrolls() {
+		return fmt.Sprintf("Controls: %s/%s/%s/%s move, %s/%s aim, %s/%s pan, 1-4 arrow, %s shoot (hold to charge), %s reload, %s pause, %s quit",
+			label(k.up), label(k.down), label(k.left), label(k.right), label(k.aimUp), label(k.aimDown),
+			label(k.panLeft), label(k.panRight), label(k.shoot), label(k.reload), label(k.pause), label(k.quit))
+	}
+	return fmt.Sprintf("Controls: %s/%s/%s/%s move, %s/%s aim, 1-4 arrow, %s shoot (hold to charge), %s reload, %s pause, %s quit",
+		label(k.up), label(k.down), label(k.left), label(k.right), label(k.aimUp), label(k.aimDown),
+		label(k.shoot), label(k.reload), label(k.pause), label(k.quit))
+}
+
+// keyName is how a pressed key is written under [keys]
+func keyName(msg tea.KeyMsg) string {
+	if s := msg.String(); s != " " {
+		return s
+	}
+	return "space"
+}
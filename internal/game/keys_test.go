@@ -0,0 +1,49 @@
+package game
+
+import (
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/ashX04/gobowarrow/internal/config"
+)
+
+func press(t *testing.T, g Game, key string) Game {
+	t.Helper()
+	m, _ := g.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(key)})
+	return m.(Game)
+}
+
+func TestPauseBoundToALetterTheGameUses(t *testing.T) {
+	for _, k := range []string{"d", "m", "o", "r"} {
+		t.Run(k, func(t *testing.T) {
+			cfg := config.Default()
+			cfg.Seed = 1
+			cfg.Keys.Pause = []string{k}
+			cfg.Keys.Reload = []string{"x"}
+			if err := cfg.Validate(); err != nil {
+				t.Fatal(err)
+			}
+			g := New(cfg).Start()
+
+			if g = press(t, g, k); g.state != paused {
+				t.Fatalf("state %v after %s, want paused", g.state, k)
+			}
+			if g = press(t, g, k); g.state != playing {
+				t.Errorf("state %v after a second %s, want playing", g.state, k)
+			}
+		})
+	}
+}
+
+func TestDifficultyOnlyCyclesAfterTheRun(t *testing.T) {
+	g := headless(t, "classic", 1)
+	name := g.difficulty.Name
+	if g = press(t, g, "d"); g.difficulty.Name != name {
+		t.Errorf("d mid-run changed the difficulty to %s", g.difficulty.Name)
+	}
+	g.state = gameOver
+	if g = press(t, g, "d"); g.difficulty.Name == name {
+		t.Error("d on the game over screen left the difficulty alone")
+	}
+}
@@ -0,0 +1,147 @@
+package game
+
+import (
+	"context"
+	"fmt"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/ashX04/gobowarrow/internal/leaderboard"
+)
+
+// leaderboardRows is how many online entries fit on screen at once
+const leaderboardRows = 10
+
+// anonymousName is sent when the config doesn't set player_name
+const anonymousName = "anon"
+
+// onlineTable is the leaderboard screen's copy of the online table
+type onlineTable struct {
+	mode    string // mode id the entries are for
+	entries []leaderboard.Entry
+	err     error
+	loading bool
+	scroll  int // index of the first entry shown
+}
+
+// leaderboardMsg delivers a fetched table for mode
+type leaderboardMsg struct {
+	mode    string
+	entries []leaderboard.Entry
+	err     error
+}
+
+// scoreSubmittedMsg reports the result of posting a run online
+type scoreSubmittedMsg struct{ err error }
+
+// submitScore posts the finished run when the leaderboard is enabled
+func (m Game) submitScore() tea.Cmd {
+	if m.leaderboard == nil || m.score <= 0 {
+		return nil
+	}
+	name := m.playerCfg.PlayerName
+	if name == "" {
+		name = anonymousName
+	}
+	client := m.leaderboard
+	s := leaderboard.Submission{
+		Name:       name,
+		Mode:       m.currentMode().id,
+		Score:      m.score,
+		Seed:       m.seed,
+		ReplayHash: leaderboard.ReplayHash(m.record),
+	}
+	return func() tea.Msg {
+		return scoreSubmittedMsg{err: client.Submit(context.Background(), s)}
+	}
+}
+
+// openLeaderboard shows the online table for the selected mode
+func (m Game) openLeaderboard() (Game, tea.Cmd) {
+	m.state = showingLeaderboard
+	mode := m.currentMode().id
+	m.online = onlineTable{mode: mode, loading: m.leaderboard != nil}
+	if m.leaderboard == nil {
+		return m, nil
+	}
+	client := m.leaderboard
+	return m, func() tea.Msg {
+		entries, err := client.Top(context.Background(), mode, leaderboard.TopSize)
+		return leaderboardMsg{mode: mode, entries: entries, err: err}
+	}
+}
+
+// updateLeaderboard handles scrolling and switching modes on the
+// leaderboard screen
+func (m Game) updateLeaderboard(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "ctrl+c":
+		return m, tea.Quit
+	case "esc", "q", "enter", "backspace":
+		m.state = menu
+	case "up", "k":
+		m.online.scroll = max(m.online.scroll-1, 0)
+	case "down", "j":
+		m.online.scroll = min(m.online.scroll+1, max(len(m.online.entries)-leaderboardRows, 0))
+	case "left", "h":
+		m.mode = (m.mode + len(modes) - 1) % len(modes)
+		return m.openLeaderboard()
+	case "right", "l":
+		m.mode = (m.mode + 1) % len(modes)
+		return m.openLeaderboard()
+	}
+	return m, nil
+}
+
+// leaderboardView renders the online table a page at a time
+func (m Game) leaderboardView() string {
+	modeStyle := lipgloss.NewStyle().
+		Foreground(m.theme.Accent).
+		Bold(true)
+
+	headerStyle := lipgloss.NewStyle().
+		Foreground(m.theme.Title).
+		Bold(true).
+		MarginTop(1)
+
+	rowStyle := lipgloss.NewStyle().Foreground(m.theme.Text)
+
+	hintStyle := lipgloss.NewStyle().
+		Foreground(m.theme.Muted).
+		MarginTop(1)
+
+	var body string
+	t := m.online
+	switch {
+	case m.leaderboard == nil:
+		body = hintStyle.Render("The online leaderboard is off.\nSet leaderboard_url in config.toml to join in.")
+	case t.loading:
+		body = hintStyle.Render("Loading…")
+	case t.err != nil:
+		body = hintStyle.Render("Leaderboard unavailable. Check your connection.")
+	case len(t.entries) == 0:
+		body = headerStyle.Render("No scores yet. Be the first!")
+	default:
+		end := min(t.scroll+leaderboardRows, len(t.entries))
+		rows := []string{headerStyle.Render(fmt.Sprintf("ONLINE TOP %d", leaderboard.TopSize))}
+		for i, e := range t.entries[t.scroll:end] {
+			// Servers may leave ranks out; the list is already in order
+			if e.Rank == 0 {
+				e.Rank = t.scroll + i + 1
+			}
+			rows = append(rows, rowStyle.Render(fmt.Sprintf("%3d. %-16s %6d", e.Rank, e.Name, e.Score)))
+		}
+		rows = append(rows, hintStyle.UnsetMarginTop().Render(
+			fmt.Sprintf("%d–%d of %d", t.scroll+1, end, len(t.entries)),
+		))
+		body = lipgloss.JoinVertical(lipgloss.Left, rows...)
+	}
+
+	return m.framedScreen(lipgloss.JoinVertical(
+		lipgloss.Center,
+		modeStyle.Render("◀ "+m.currentMode().name+" ▶"),
+		body,
+		hintStyle.Render("↑/↓ scroll, ←/→ change mode, ESC to go back"),
+	))
+}
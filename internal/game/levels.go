@@ -0,0 +1,136 @@
+package game
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/ashX04/gobowarrow/internal/assets"
+	"github.com/ashX04/gobowarrow/internal/config"
+	"github.com/ashX04/gobowarrow/internal/level"
+	"github.com/ashX04/gobowarrow/internal/script"
+)
+
+// levelMode describes a loaded level as a game mode so the usual
+// end conditions and per-mode high scores apply
+func levelMode(l *level.Level) gameMode {
+	description := fmt.Sprintf("Reach %d points to clear the level", l.Win.Score)
+	if l.Win.Score == 0 {
+		description = "Clear the level"
+	}
+	return gameMode{
+		id:          "level-" + strings.ToLower(strings.ReplaceAll(l.Name, " ", "-")),
+		name:        l.Name,
+		description: description,
+		escapeLimit: l.Win.MaxEscaped,
+		timeLimit:   l.Win.TimeLimit,
+	}
+}
+
+// CheckLevel makes sure every sprite a level names exists, that its
+// weather is one the game has and that its script compiles
+func CheckLevel(l *level.Level) error {
+	if l.Source != "" {
+		if err := script.Check(l.Script, l.Source); err != nil {
+			return fmt.Errorf("script: %w", err)
+		}
+	}
+	if l.Weather != "" && !slices.Contains(config.Weathers, l.Weather) {
+		return fmt.Errorf("weather must be one of %s, got %q", strings.Join(config.Weathers, ", "), l.Weather)
+	}
+	for i, b := range l.Balloons {
+		if len(b.Art) == 0 {
+			if _, ok := assets.Find(b.Sprite); !ok {
+				return fmt.Errorf("balloon %d: unknown sprite %q", i, b.Sprite)
+			}
+		}
+	}
+	return nil
+}
+
+// levelWon reports whether the level's target score has been reached, or
+// its script says it's won
+func (m Game) levelWon() bool {
+	if m.level == nil {
+		return false
+	}
+	return m.level.Win.Score > 0 && m.score >= m.level.Win.Score || m.script != nil && m.script.Won()
+}
+
+// levelExhausted reports whether a finite level has nothing left to pop
+func (m Game) levelExhausted() bool {
+	q := m.level.Spawn.Quota
+	return q > 0 && m.levelSpawned >= q && len(m.balloons) == 0
+}
+
+// spawnLevelBalloons spawns balloons following the level's pattern
+func (m *Game) spawnLevelBalloons() {
+	spawn := m.level.Spawn
+
+	count := 0
+	switch spawn.Pattern {
+	case level.PatternRandom:
+		if m.rng.Float64() < m.difficulty.SpawnChance(spawn.Chance) {
+			count = 1
+		}
+	case level.PatternStream, level.PatternBurst:
+		every := max(int(spawn.Interval*float64(m.cfg.TickRate)), 1)
+		if m.timer%every == 0 {
+			count = 1
+			if spawn.Pattern == level.PatternBurst {
+				count = spawn.BurstSize
+			}
+		}
+	}
+
+	for range count {
+		if spawn.Quota > 0 && m.levelSpawned >= spawn.Quota {
+			return
+		}
+		art, color, marker := m.pickLevelBalloon()
+		b := m.newBalloon(art, color)
+		b.Marker = marker
+		m.balloons = append(m.balloons, b)
+		m.levelSpawned++
+	}
+}
+
+// pickLevelBalloon chooses a balloon type by weight and resolves its art.
+// Only built-in sprites have a marker.
+func (m Game) pickLevelBalloon() ([]string, lipgloss.Color, string) {
+	roll := m.rng.Intn(max(m.level.TotalWeight(), 1))
+	choice := m.level.Balloons[0]
+	for _, b := range m.level.Balloons {
+		if roll < b.Weight {
+			choice = b
+			break
+		}
+		roll -= b.Weight
+	}
+
+	art := choice.Art
+	color := lipgloss.Color(choice.Color)
+	marker := ""
+	if len(art) == 0 {
+		s, _ := assets.Find(choice.Sprite)
+		art, marker = s.Art, m.marker(s.Marker, s.Letter)
+		if choice.Color == "" {
+			color = m.theme.Balloon(s.Name, s.Color)
+		}
+	}
+	if color == "" {
+		round := assets.Balloons[0]
+		color = m.theme.Balloon(round.Name, round.Color)
+	}
+	return art, color, marker
+}
+
+// applyWind is the level's sideways drift in cells per second
+func (m Game) applyWind() float64 {
+	if m.level == nil {
+		return 0
+	}
+	return m.level.Wind
+}
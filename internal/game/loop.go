@@ -0,0 +1,79 @@
+package game
+
+import (
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/ashX04/gobowarrow/internal/config"
+)
+
+// maxFrameLag caps how much simulation time one frame may catch up on, so
+// a stalled terminal doesn't fast-forward the game in one burst
+const maxFrameLag = 250 * time.Millisecond
+
+// frameMsg asks for a redraw and lets the simulation catch up to now
+type frameMsg time.Time
+
+// frame schedules the next redraw at the configured frame rate
+func frame(cfg config.Config) tea.Cmd {
+	return tea.Tick(cfg.FrameInterval(), func(t time.Time) tea.Msg {
+		return frameMsg(t)
+	})
+}
+
+// advance runs as many fixed simulation ticks as real time allows, then
+// redraws. Whatever time is left over is used to interpolate positions.
+func (m Game) advance(now time.Time) (tea.Model, tea.Cmd) {
+	m.syncMusic()
+	m.sampleDebug(now)
+	// Settings opened from the pause screen, dialogs, the countdown back
+	// in and a terminal too small to show the board hold the run like a
+	// pause
+	held := m.state == paused || m.state == settings && m.form.from == paused ||
+		m.state == playing && (m.dialog.open() || m.tooSmall() || now.Before(m.resumeAt))
+	// Stop the frame loop outside of play; starting a game re-arms it
+	if m.state != playing && !held {
+		m.lastFrame = time.Time{}
+		return m, nil
+	}
+	// Hold the simulation while paused, but keep the frame loop alive
+	if held || m.lastFrame.IsZero() {
+		m.lastFrame = now
+		return m, frame(m.cfg)
+	}
+
+	m.lag += min(now.Sub(m.lastFrame), maxFrameLag)
+	m.lastFrame = now
+	interval := m.cfg.TickInterval()
+	for m.lag >= interval {
+		m.lag -= interval
+		// A hit-stop holds the action for a tick of real time
+		if m.juice.hitStop > 0 {
+			m.juice.hitStop--
+			continue
+		}
+		var cmd tea.Cmd
+		m, cmd = m.step(now)
+		if m.debug != nil {
+			m.debug.ticks++
+		}
+		if m.state != playing {
+			m.syncMusic()
+			return m, tea.Batch(cmd, m.playSounds())
+		}
+	}
+	return m, tea.Batch(frame(m.cfg), m.playSounds())
+}
+
+// dt is the length of one simulation tick in seconds. Movement is
+// expressed per second and scaled by dt, so changing the tick rate changes
+// smoothness but not game speed.
+func (m Game) dt() float64 {
+	return m.cfg.TickInterval().Seconds()
+}
+
+// alpha is how far between the last tick and the next one this frame is
+func (m Game) alpha() float64 {
+	return min(float64(m.lag)/float64(m.cfg.TickInterval()), 1)
+}
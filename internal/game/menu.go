@@ -0,0 +1,245 @@
+package game
+
+import (
+	"fmt"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/ashX04/gobowarrow/internal/daily"
+	"github.com/ashX04/gobowarrow/internal/difficulty"
+)
+
+// Main menu entries, in display order
+const (
+	menuResume = iota // only shown when there's a saved run
+	menuStart
+	menuMode
+	menuDifficulty
+	menuScores
+	menuLeaderboard
+	menuSettings
+	menuQuit
+	menuItemCount
+)
+
+// updateMenu handles input on the title screen
+func (m Game) updateMenu(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "q", "ctrl+c":
+		return m, tea.Quit
+	case "up", "k":
+		m.menuCursor = m.stepMenu(-1)
+	case "down", "j":
+		m.menuCursor = m.stepMenu(1)
+	case "left", "h":
+		m = m.cycleMenuOption(-1)
+	case "right", "l":
+		m = m.cycleMenuOption(1)
+	case "enter", " ":
+		switch m.menuCursor {
+		case menuResume:
+			return m.resume()
+		case menuStart:
+			return m.startRun()
+		case menuMode, menuDifficulty:
+			m = m.cycleMenuOption(1)
+		case menuScores:
+			m.state = showingScores
+		case menuLeaderboard:
+			return m.openLeaderboard()
+		case menuSettings:
+			m = m.openSettings()
+		case menuQuit:
+			return m, tea.Quit
+		}
+	}
+	return m, nil
+}
+
+// stepMenu is the menu entry step entries on from the cursor, wrapping
+// around at either end and skipping Resume when there's nothing to resume
+func (m Game) stepMenu(step int) int {
+	first := menuStart
+	if m.saved != nil {
+		first = menuResume
+	}
+	n := menuItemCount - first
+	return first + (m.menuCursor-first+n+step)%n
+}
+
+// cycleMenuOption steps the mode or difficulty under the cursor
+func (m Game) cycleMenuOption(step int) Game {
+	switch m.menuCursor {
+	case menuMode:
+		// A level loaded with --level replaces the mode choice
+		if m.level == nil {
+			m.mode = (m.mode + len(modes) + step) % len(modes)
+		}
+	case menuDifficulty:
+		// The daily challenge plays on the default difficulty
+		if m.currentMode().daily {
+			break
+		}
+		m.difficulty, _ = difficulty.Lookup(cycle(difficulty.Names(), m.difficulty.Name, step))
+		m.playerCfg.Difficulty = m.difficulty.Name
+	}
+	return m
+}
+
+// updateSubScreen handles the scores screen
+func (m Game) updateSubScreen(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "ctrl+c":
+		return m, tea.Quit
+	case "esc", "q", "enter", "backspace":
+		m.state = menu
+	case "left", "h":
+		// Browse the per-mode tables
+		if m.state == showingScores {
+			m.mode = (m.mode + len(modes) - 1) % len(modes)
+		}
+	case "right", "l":
+		if m.state == showingScores {
+			m.mode = (m.mode + 1) % len(modes)
+		}
+	}
+	return m, nil
+}
+
+// menuView renders the title screen
+func (m Game) menuView() string {
+	titleStyle := lipgloss.NewStyle().
+		Foreground(m.theme.Title).
+		Bold(true).
+		MarginBottom(1)
+
+	itemStyle := lipgloss.NewStyle().
+		Foreground(m.theme.Text).
+		PaddingLeft(2)
+
+	selectedStyle := lipgloss.NewStyle().
+		Foreground(m.theme.Accent).
+		Bold(true)
+
+	descStyle := lipgloss.NewStyle().
+		Foreground(m.theme.Muted).
+		MarginTop(1)
+
+	difficultyLabel := fmt.Sprintf("Difficulty: ◀ %s ▶", m.difficulty.Name)
+	description := m.currentMode().description
+	modeName := m.currentMode().name
+	if m.modeLocked() {
+		modeName += " 🔒"
+		description = m.lockedNote()
+	}
+	if m.currentMode().daily {
+		difficultyLabel = fmt.Sprintf("Difficulty: %s (locked)", m.difficulty.Name)
+		if r, played := m.dailyPlayed(); played {
+			description = fmt.Sprintf("Today's daily is done: %d points. Back tomorrow!", r.Score)
+		}
+	}
+	if m.menuCursor == menuResume && m.saved != nil {
+		description = m.savedNote()
+	}
+
+	labels := []string{
+		"Resume last game",
+		"Start game",
+		fmt.Sprintf("Mode: ◀ %s ▶", modeName),
+		difficultyLabel,
+		"High scores",
+		"Leaderboard",
+		"Settings",
+		"Quit",
+	}
+
+	var items []string
+	for i, label := range labels {
+		switch {
+		case i == menuResume && m.saved == nil:
+			continue
+		case i == m.menuCursor:
+			items = append(items, selectedStyle.Render("▸ "+label))
+		default:
+			items = append(items, itemStyle.Render(label))
+		}
+	}
+
+	lines := []string{
+		titleStyle.Render("🎯 Balloon Archer 🎈"),
+		lipgloss.JoinVertical(lipgloss.Left, items...),
+		descStyle.Render(description),
+	}
+	if m.profile != nil {
+		lines = append(lines, descStyle.UnsetMarginTop().Render(m.levelLine()))
+	}
+	lines = append(lines, descStyle.UnsetMarginTop().Render("↑/↓ select, ←/→ change, ENTER confirm, q quit"))
+
+	return m.framedScreen(lipgloss.JoinVertical(lipgloss.Left, lines...))
+}
+
+// scoresView renders the high-score table on its own screen
+func (m Game) scoresView() string {
+	modeStyle := lipgloss.NewStyle().
+		Foreground(m.theme.Accent).
+		Bold(true)
+
+	hintStyle := lipgloss.NewStyle().
+		Foreground(m.theme.Muted).
+		MarginTop(1)
+
+	mode := m.currentMode()
+	table := m.highScoreTable(m.highScores.ForMode(mode.id))
+	if mode.daily {
+		table = m.dailyCalendar(m.dailyHistory, daily.Today())
+	}
+	return m.framedScreen(lipgloss.JoinVertical(
+		lipgloss.Center,
+		modeStyle.Render("◀ "+mode.name+" ▶"),
+		table,
+		hintStyle.Render("←/→ change mode, ESC to go back"),
+	))
+}
+
+// cycle steps from current to the next or previous of names, wrapping
+// around at either end
+func cycle(names []string, current string, step int) string {
+	i := 0
+	for j, name := range names {
+		if name == current {
+			i = j
+		}
+	}
+	return names[(i+len(names)+step)%len(names)]
+}
+
+// seedLabel describes a configured seed
+func seedLabel(seed int64) string {
+	if seed == 0 {
+		return "random"
+	}
+	return fmt.Sprint(seed)
+}
+
+// leaderboardLabel describes the configured leaderboard server
+func leaderboardLabel(url string) string {
+	if url == "" {
+		return "off"
+	}
+	return url
+}
+
+// framedScreen centers content in a bordered box the size of the board
+func (m Game) framedScreen(content string) string {
+	boxStyle := lipgloss.NewStyle().
+		BorderStyle(lipgloss.RoundedBorder()).
+		BorderForeground(m.theme.Border).
+		Padding(1, 4)
+
+	return lipgloss.Place(
+		m.viewWidth()+4, m.height+6,
+		lipgloss.Center, lipgloss.Center,
+		boxStyle.Render(content),
+	)
+}
@@ -0,0 +1,197 @@
+package game
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/progress"
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/ashX04/gobowarrow/internal/theme"
+)
+
+// timeAttackSeconds is the length of a Time Attack round
+const timeAttackSeconds = 60
+
+// survivalLives is how many escaped balloons Survival tolerates
+const survivalLives = 3
+
+// zenPace is how fast Zen's balloons rise, as a fraction of the usual
+const zenPace = 0.5
+
+// gameMode describes a way to play selectable from the menu
+type gameMode struct {
+	id          string // stable key used for per-mode high scores
+	name        string
+	description string
+	escapeLimit int     // escaped balloons that end the run; 0 disables
+	timeLimit   int     // seconds before the run ends; 0 disables
+	lives       int     // lives lost to escaped balloons; 0 disables
+	daily       bool    // today's shared challenge with locked settings
+	typing      bool    // balloons carry words, and typing one shoots at it
+	math        bool    // balloons carry numbers, and only the sum's answer scores
+	oneMiss     bool    // an arrow off the right edge without a hit ends the run
+	zen         bool    // nothing is scored and nothing ends the run
+	pace        float64 // fraction of the usual speed balloons rise at; 0 leaves it
+	theme       string  // built-in theme it's drawn in unless the player picked another
+	targets     bool    // a range of still bullseyes in place of balloons
+	viewers     bool    // chat can send up balloons of its own
+}
+
+var modes = []gameMode{
+	{
+		id:          "classic",
+		name:        "Classic",
+		description: fmt.Sprintf("Pop balloons until %d escape", maxEscaped),
+		escapeLimit: maxEscaped,
+		viewers:     true,
+	},
+	{
+		id:          "time-attack",
+		name:        "Time Attack",
+		description: fmt.Sprintf("Score as much as you can in %d seconds", timeAttackSeconds),
+		timeLimit:   timeAttackSeconds,
+		viewers:     true,
+	},
+	{
+		id:          "survival",
+		name:        "Survival",
+		description: fmt.Sprintf("Every escaped balloon costs one of %d lives", survivalLives),
+		lives:       survivalLives,
+		viewers:     true,
+	},
+	{
+		id:          "typing",
+		name:        "Typing",
+		description: "Type the word on a balloon to shoot it",
+		escapeLimit: maxEscaped,
+		typing:      true,
+	},
+	{
+		id:          "math",
+		name:        "Math",
+		description: "Pop the balloon with the answer to the sum; wrong ones cost points",
+		timeLimit:   mathSeconds,
+		math:        true,
+	},
+	{
+		id:          "hardcore",
+		name:        "Hardcore",
+		description: "One missed arrow or one escaped balloon and it's over",
+		escapeLimit: 1,
+		oneMiss:     true,
+	},
+	{
+		id:          "zen",
+		name:        "Zen",
+		description: "Balloons drift by slowly. No score, no clock, no way to lose",
+		zen:         true,
+		pace:        zenPace,
+		theme:       theme.Zen,
+		viewers:     true,
+	},
+	{
+		id:          "range",
+		name:        "Practice Range",
+		description: fmt.Sprintf("%d arrows at bullseyes near and far. Rings score more toward the middle", rangeShots),
+		targets:     true,
+	},
+	{
+		id:          "daily",
+		name:        "Daily",
+		description: "Today's balloons are the same for everyone. One attempt per day!",
+		escapeLimit: maxEscaped,
+		daily:       true,
+	},
+}
+
+// labelled reports whether the mode writes on its balloons
+func (g gameMode) labelled() bool {
+	return g.typing || g.math
+}
+
+// currentMode returns the mode selected for this run
+func (m Game) currentMode() gameMode {
+	if m.level != nil {
+		return levelMode(m.level)
+	}
+	return modes[m.mode]
+}
+
+// runTheme is the theme named name, from the built-ins or custom, unless
+// the player has kept the default and the current mode has its own
+func (m Game) runTheme(name string, custom map[string]theme.Theme) theme.Theme {
+	if own := m.currentMode().theme; own != "" && name == theme.Default {
+		name = own
+	}
+	palette, ok := theme.Lookup(name, custom)
+	if !ok {
+		palette, _ = theme.Lookup(theme.Default, nil)
+	}
+	return palette
+}
+
+// timeLimitTicks converts the mode's time limit to simulation ticks
+func (m Game) timeLimitTicks() int {
+	return m.currentMode().timeLimit * m.cfg.TickRate
+}
+
+// timeLeft returns the remaining seconds in a timed mode
+func (m Game) timeLeft() float64 {
+	left := m.timeLimitTicks() - m.timer
+	return float64(max(left, 0)) / float64(m.cfg.TickRate)
+}
+
+// runOver reports whether the mode's end condition has been met
+func (m Game) runOver() bool {
+	mode := m.currentMode()
+	if mode.timeLimit > 0 && m.timer >= m.timeLimitTicks() {
+		return true
+	}
+	// God mode only leaves the clock and a level's goal to end the run
+	if !m.god {
+		if limit := m.escapeLimit(); limit > 0 && m.escaped >= limit {
+			return true
+		}
+		if mode.lives > 0 && m.lives <= 0 {
+			return true
+		}
+		if mode.oneMiss && m.strays > 0 {
+			return true
+		}
+	}
+	if mode.targets && m.rangeDone() {
+		return true
+	}
+	if m.level != nil {
+		return m.levelWon() || m.levelExhausted() || m.scriptLost()
+	}
+	return false
+}
+
+// hearts renders remaining lives as filled and empty hearts
+func hearts(lives, total int) string {
+	lives = min(max(lives, 0), total)
+	return strings.Repeat("♥", lives) + strings.Repeat("♡", total-lives)
+}
+
+// meter renders a bubbles progress bar width cells wide, filled to
+// fraction (0..1) with a gradient between two theme colors. The gradient
+// spans the whole bar, so it shifts toward to as the bar fills.
+func (m Game) meter(width int, fraction float64, from, to theme.Color) string {
+	bar := progress.New(
+		progress.WithGradient(from.Hex(), to.Hex()),
+		progress.WithWidth(width),
+		progress.WithoutPercentage(),
+		progress.WithColorProfile(lipgloss.ColorProfile()),
+	)
+	bar.EmptyColor = m.theme.Faint.Hex()
+	return bar.ViewAs(min(max(fraction, 0), 1))
+}
+
+// progressBar renders a fixed-width bar filled to fraction (0..1)
+func progressBar(width int, fraction float64) string {
+	fraction = min(max(fraction, 0), 1)
+	filled := int(fraction*float64(width) + 0.5)
+	return strings.Repeat("█", filled) + strings.Repeat("░", width-filled)
+}
@@ -0,0 +1,88 @@
+package game
+
+import (
+	"time"
+
+	"github.com/ashX04/gobowarrow/internal/physics"
+)
+
+// holdDelay is how long an arrow key must be held before the archer glides
+// at the configured repeat speed instead of stepping once per key repeat
+const holdDelay = 250 * time.Millisecond
+
+// moveReleaseGap ends a glide. It is tighter than releaseGap since every
+// tick spent gliding after the key is let go carries the archer past
+// where the player stopped.
+const moveReleaseGap = 100 * time.Millisecond
+
+// bowReach is how many columns right of the archer an arrow leaves the bow
+const bowReach = 2
+
+// step is one cell of archer movement: dx is -1 left or 1 right, dy is -1
+// up or 1 down
+type step struct{ dx, dy int }
+
+// mover tracks a held arrow key. Like the space bar, holding is detected
+// from key repeat; see repeatWindow.
+type mover struct {
+	dir       step      // zero when no key is down
+	heldSince time.Time // first press of the current hold
+	lastPress time.Time
+	gliding   bool    // moving every tick rather than per press
+	carry     float64 // fraction of a row travelled while gliding
+}
+
+// pressMove handles a movement key press. A tap moves one cell; once the
+// key has been held past holdDelay the archer glides until it's let go.
+func (m *Game) pressMove(dir step, now time.Time) {
+	mv := &m.mover
+	held := dir == mv.dir && now.Sub(mv.lastPress) < repeatWindow
+	mv.lastPress = now
+	switch {
+	case !held:
+		*mv = mover{dir: dir, heldSince: now, lastPress: now}
+		m.moveArcher(dir)
+	case mv.gliding:
+		// Key repeat while gliding just keeps the glide going
+	case now.Sub(mv.heldSince) >= holdDelay:
+		mv.gliding = true
+	default:
+		m.moveArcher(dir)
+	}
+}
+
+// tickMove glides a held archer, stopping once the key is released
+func (m *Game) tickMove(now time.Time) {
+	mv := &m.mover
+	if !mv.gliding {
+		return
+	}
+	if now.Sub(mv.lastPress) > moveReleaseGap {
+		*mv = mover{}
+		return
+	}
+	mv.carry += m.cfg.MoveRepeat * m.dt()
+	for mv.carry >= 1 {
+		mv.carry--
+		m.moveArcher(mv.dir)
+	}
+}
+
+// moveArcher steps the archer one cell, stopping at the board's top and
+// bottom edges and the end of the left third
+func (m *Game) moveArcher(dir step) {
+	m.archer = min(max(m.archer+dir.dy, 0), m.height-1)
+	m.archerCol = min(max(m.archerCol+dir.dx, 0), m.maxArcherCol())
+	m.movedAt = m.timer
+	m.lean(dir)
+}
+
+// maxArcherCol is the furthest right the archer can stand
+func (m Game) maxArcherCol() int {
+	return m.viewWidth() / 3
+}
+
+// launchFrom is where an arrow leaves the bow of an archer at (col, row)
+func launchFrom(col, row int) physics.Vec {
+	return physics.Vec{X: float64(col + bowReach), Y: float64(row)}
+}
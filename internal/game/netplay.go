@@ -0,0 +1,204 @@
+package game
+
+import (
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/ashX04/gobowarrow/internal/entities"
+	"github.com/ashX04/gobowarrow/internal/netplay"
+	"github.com/ashX04/gobowarrow/internal/physics"
+	"github.com/ashX04/gobowarrow/internal/render"
+)
+
+// guestStartGap is how many rows below the host the guest starts
+const guestStartGap = 4
+
+// guestStyle is how the guest's archer and arrows are drawn
+func (m Game) guestStyle() render.Style {
+	return render.Style{FG: m.theme.Guest}
+}
+
+// guest is the second archer in a networked match. The host simulates it
+// alongside its own archer, so both compete for the same balloons.
+type guest struct {
+	archer int
+	col    int
+	aim    int
+	score  int
+	combo  int // consecutive hits, for the guest's own multiplier
+}
+
+// tally returns the score and combo an archer's hits count toward: the
+// host's, or the guest's for the guest's arrows. Arrows the guest left in
+// flight when they went earn nothing.
+func (m *Game) tally(guest bool) (score, combo *int) {
+	switch {
+	case !guest:
+		return &m.score, &m.combo
+	case m.guest != nil:
+		return &m.guest.score, &m.guest.combo
+	}
+	return new(int), new(int)
+}
+
+// countHit marks arrow a as having hit something. Only the host's shots
+// are counted, so only their hits go toward accuracy.
+func (m *Game) countHit(a *entities.Arrow) {
+	if a.Hit {
+		return
+	}
+	a.Hit = true
+	if !a.Guest {
+		m.hits++
+	}
+}
+
+// newGuest places the guest a few rows below the host's archer
+func (m Game) newGuest() *guest {
+	return &guest{archer: min(m.archer+guestStartGap, m.height-1)}
+}
+
+// guestMsg carries one message from the guest's terminal
+type guestMsg struct {
+	msg netplay.Message
+	err error
+}
+
+// handleGuestKey applies a key press from the guest. Guests get the basic
+// controls: move, aim and tap to shoot.
+func (m Game) handleGuestKey(key string) Game {
+	g := m.guest
+	if g == nil || m.state != playing {
+		return m
+	}
+	switch key {
+	case "up":
+		g.archer = max(g.archer-1, 0)
+	case "down":
+		g.archer = min(g.archer+1, m.height-1)
+	case "left":
+		g.col = max(g.col-1, 0)
+	case "right":
+		g.col = min(g.col+1, m.maxArcherCol())
+	case "w":
+		g.aim = max(g.aim-1, -maxAim)
+	case "s":
+		g.aim = min(g.aim+1, maxAim)
+	case " ":
+		m.guestShoot()
+	}
+	return m
+}
+
+// guestShoot launches a standard arrow from the guest's bow
+func (m *Game) guestShoot() {
+	g := m.guest
+	inFlight := 0
+	for _, a := range m.arrows {
+		if a.Guest {
+			inFlight++
+		}
+	}
+	if inFlight >= m.difficulty.MaxArrows(m.cfg.MaxArrows) {
+		return
+	}
+	from := launchFrom(g.col, g.archer)
+	arrow := entities.Arrow{
+		Body:   physics.Launch(from, float64(m.cfg.ArrowSpeed), float64(g.aim)*aimStep),
+		Kind:   standardArrow,
+		Active: true,
+		Symbol: m.arrowSymbol(standardArrow, false),
+		Guest:  true,
+		Prev:   from,
+		Origin: from,
+	}
+	m.arrows = append(m.arrows, arrow)
+}
+
+// drawGuest draws the guest's archer
+func (m Game) drawGuest(f *render.FrameBuffer, dim bool) {
+	if m.guest == nil {
+		return
+	}
+	style := m.guestStyle()
+	if dim {
+		style = m.dimStyle()
+	}
+	f.Set(m.guest.col, m.guest.archer, "|)", style)
+}
+
+// Host runs a networked match. The local player plays as usual while the
+// guest, connected over TCP, controls a second archer on the same board
+// and is sent every frame.
+type Host struct {
+	game Game
+	conn *netplay.Conn
+}
+
+// NewHost starts a match between the local player and the guest on conn
+func NewHost(g Game, conn *netplay.Conn) Host {
+	g = g.Start()
+	g.guest = g.newGuest()
+	g.notify("🤝 Player 2 joined", g.theme.Success)
+	return Host{game: g, conn: conn}
+}
+
+// listen waits for the guest's next message
+func (h Host) listen() tea.Cmd {
+	return func() tea.Msg {
+		msg, err := h.conn.Receive()
+		return guestMsg{msg: msg, err: err}
+	}
+}
+
+func (h Host) Init() tea.Cmd {
+	return tea.Batch(h.game.Init(), h.listen())
+}
+
+func (h Host) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	var cmd tea.Cmd
+	// The guest's screen is drawn on each frame and on either player's keys,
+	// which are all that move the menus on once the frame loop has stopped,
+	// rather than on every message
+	redraw := false
+	switch msg := msg.(type) {
+	case guestMsg:
+		if msg.err != nil || msg.msg.Type == netplay.ByeMsg {
+			// Play on alone once the guest has gone
+			h.conn.Close()
+			h.game.guest = nil
+			h.game.notify("🤝 Player 2 left", h.game.theme.Danger)
+			return h, nil
+		}
+		if msg.msg.Type == netplay.KeyMsg {
+			h.game = h.game.handleGuestKey(msg.msg.Key)
+			redraw = true
+		}
+		cmd = h.listen()
+
+	case tea.KeyMsg:
+		if h.game.quits(msg) {
+			h.conn.Close()
+		}
+		next, c := h.game.Update(msg)
+		h.game, cmd = next.(Game), c
+		redraw = true
+
+	case frameMsg:
+		next, c := h.game.Update(msg)
+		h.game, cmd = next.(Game), c
+		redraw = true
+
+	default:
+		next, c := h.game.Update(msg)
+		h.game, cmd = next.(Game), c
+	}
+
+	if redraw && h.game.guest != nil {
+		h.conn.Send(netplay.Message{Type: netplay.FrameMsg, Frame: h.game.View()})
+	}
+	return h, cmd
+}
+
+func (h Host) View() string {
+	return h.game.View()
+}
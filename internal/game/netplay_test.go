@@ -0,0 +1,74 @@
+package game
+
+import (
+	"testing"
+
+	"github.com/ashX04/gobowarrow/internal/entities"
+)
+
+// matchWithGolden sets up a networked match with one golden balloon that
+// carries rapid fire on the board
+func matchWithGolden(t *testing.T) Game {
+	t.Helper()
+	g := headless(t, "classic", 1)
+	g.guest = g.newGuest()
+	b := g.newGoldenBalloon()
+	b.PowerUp = rapidFire
+	g.balloons = []entities.Balloon{b}
+	return g
+}
+
+func TestGuestPopIsTheGuests(t *testing.T) {
+	g := matchWithGolden(t)
+	g.combo = 4
+	host := g
+
+	a := entities.Arrow{Kind: standardArrow, Active: true, Guest: true}
+	g.hitBalloon(&a, 0)
+
+	if g.guest.score == 0 || g.guest.combo != 1 {
+		t.Errorf("guest score %d combo %d, want the pop scored and a combo of 1", g.guest.score, g.guest.combo)
+	}
+	if g.score != host.score || g.combo != host.combo || g.bestCombo != host.bestCombo {
+		t.Errorf("host score %d combo %d best %d, want %d %d %d untouched",
+			g.score, g.combo, g.bestCombo, host.score, host.combo, host.bestCombo)
+	}
+	if g.hits != host.hits || g.coins != host.coins {
+		t.Errorf("host hits %d coins %d, want %d %d untouched", g.hits, g.coins, host.hits, host.coins)
+	}
+	if g.hasEffect(rapidFire) {
+		t.Error("the guest's pop gave the host its power-up")
+	}
+	if news := g.feed.recent(feedSize); len(news) != 0 {
+		t.Errorf("feed = %q, want nothing from the guest's pop", news)
+	}
+}
+
+func TestHostPopIsTheHosts(t *testing.T) {
+	g := matchWithGolden(t)
+
+	a := entities.Arrow{Kind: standardArrow, Active: true}
+	g.hitBalloon(&a, 0)
+
+	if g.score == 0 || g.combo != 1 || g.hits != 1 || g.coins == 0 {
+		t.Errorf("host score %d combo %d hits %d coins %d, want the pop counted", g.score, g.combo, g.hits, g.coins)
+	}
+	if !g.hasEffect(rapidFire) {
+		t.Error("the host's pop didn't grant its power-up")
+	}
+	if g.guest.score != 0 || g.guest.combo != 0 {
+		t.Errorf("guest score %d combo %d, want 0 0", g.guest.score, g.guest.combo)
+	}
+}
+
+func TestGuestMissBreaksOnlyTheirCombo(t *testing.T) {
+	g := headless(t, "classic", 1)
+	g.guest = g.newGuest()
+	g.combo, g.guest.combo = 5, 5
+
+	g.registerMiss(entities.Arrow{Guest: true})
+
+	if g.guest.combo != 0 || g.combo != 5 {
+		t.Errorf("guest combo %d host combo %d, want 0 5", g.guest.combo, g.combo)
+	}
+}
@@ -0,0 +1,65 @@
+package game
+
+import (
+	"fmt"
+
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/ashX04/gobowarrow/internal/render"
+)
+
+// popupTicks is how long a score popup floats before it disappears
+const popupTicks = 8
+
+// popupRise is how many rows a popup drifts upward each tick
+const popupRise = 0.5
+
+// popup is floating text left behind where points were scored
+type popup struct {
+	x, y  float64
+	text  string
+	color lipgloss.Color
+	ticks int // ticks since it appeared
+}
+
+// addPopup floats text upward from the center of the given cell
+func (m *Game) addPopup(x, y float64, text string, color lipgloss.Color) {
+	m.popups = append(m.popups, popup{x: x - float64(len(text))/2, y: y, text: text, color: color})
+}
+
+// scorePopup describes points earned, noting the long shot bonus and the
+// multiplier when they applied
+func scorePopup(points, bonus, multiplier int) string {
+	text := fmt.Sprintf("+%d", points)
+	if bonus > 0 {
+		text += fmt.Sprintf(" +%d long", bonus)
+	}
+	if multiplier > 1 {
+		text += fmt.Sprintf(" x%d", multiplier)
+	}
+	return text
+}
+
+// tickPopups drifts every popup upward and drops the expired ones
+func (m *Game) tickPopups() {
+	live := m.popups[:0]
+	for _, p := range m.popups {
+		p.ticks++
+		p.y -= popupRise
+		if p.ticks < popupTicks && p.y >= 0 {
+			live = append(live, p)
+		}
+	}
+	m.popups = live
+}
+
+// drawPopups renders popups over the board, fading them as they age
+func (m Game) drawPopups(f *render.FrameBuffer) {
+	for _, p := range m.popups {
+		style := render.Style{FG: p.color, Bold: true}
+		if p.ticks >= popupTicks/2 {
+			style = render.Style{FG: p.color, Faint: true}
+		}
+		f.Text(int(p.y), max(int(p.x), 0), p.text, style)
+	}
+}
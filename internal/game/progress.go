@@ -0,0 +1,148 @@
+package game
+
+import (
+	"fmt"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/ashX04/gobowarrow/internal/assets"
+	"github.com/ashX04/gobowarrow/internal/profile"
+	"github.com/ashX04/gobowarrow/internal/theme"
+)
+
+// bowSkin is how an unlocked bow skin colors the archer and its arrows
+type bowSkin struct {
+	archer, arrow lipgloss.Color
+}
+
+// bowSkins are the bow skins levels unlock, by ID
+var bowSkins = map[string]bowSkin{
+	"yew":    {archer: "107", arrow: "143"},
+	"gilded": {archer: "220", arrow: "178"},
+	"frost":  {archer: "153", arrow: "117"},
+	"ember":  {archer: "202", arrow: "209"},
+}
+
+// balloonPacks are the balloon colors levels unlock, by ID, for each
+// built-in balloon sprite
+var balloonPacks = map[string]map[string]lipgloss.Color{
+	"sunset": {"round": "209", "oval": "203", "ring": "215", "dot": "175"},
+	"ocean":  {"round": "39", "oval": "31", "ring": "80", "dot": "122"},
+	"candy":  {"round": "219", "oval": "213", "ring": "159", "dot": "229"},
+}
+
+type profileSavedMsg struct{ err error }
+
+// WithProfile attaches the player's lifetime progress, saved to path
+// after each run. Without one nothing is locked.
+func (m Game) WithProfile(p profile.Profile, path string) Game {
+	m.profile = &p
+	m.profilePath = path
+	return m
+}
+
+// unlocked reports whether the player has reached the level that opens
+// the unlock of kind with id
+func (m Game) unlocked(kind profile.Kind, id string) bool {
+	return m.profile == nil || m.profile.Unlocked(kind, id)
+}
+
+// modeLocked reports whether the selected mode needs a higher level
+func (m Game) modeLocked() bool {
+	return m.level == nil && !m.unlocked(profile.Mode, m.currentMode().id)
+}
+
+// lockedNote says what level opens the selected mode
+func (m Game) lockedNote() string {
+	u, _ := profile.Lookup(profile.Mode, m.currentMode().id)
+	return fmt.Sprintf("🔒 Reach level %d to unlock %s", u.Level, m.currentMode().name)
+}
+
+// runXP is what the run earns toward the player's level: its score, and
+// a point a hit so Zen's calm runs count too
+func (m Game) runXP() int {
+	return m.score + m.hits
+}
+
+// earnXP adds the finished run's XP to the profile, noting any level it
+// reached for the game over screen, and saves the profile
+func (m *Game) earnXP() tea.Cmd {
+	if m.profile == nil {
+		return nil
+	}
+	before := *m.profile
+	after := before.Earn(m.runXP())
+	*m.profile = after
+	m.levelled = after.Level() > before.Level()
+	m.opened = after.Opened(before)
+	if m.profilePath == "" {
+		return nil
+	}
+	path := m.profilePath
+	return func() tea.Msg {
+		if err := after.Save(path); err != nil {
+			return profileSavedMsg{err: fmt.Errorf("profile: %w", err)}
+		}
+		return profileSavedMsg{}
+	}
+}
+
+// levelLine sums up the player's level and how far it is to the next
+func (m Game) levelLine() string {
+	p := *m.profile
+	level := p.Level()
+	return fmt.Sprintf("Level %d · %d/%d XP", level, p.XP, profile.LevelXP(level+1))
+}
+
+// progressView is the game over screen's account of the run's XP: what
+// it earned, the level it leaves the player on and anything it unlocked
+func (m Game) progressView() []string {
+	lines := []string{fmt.Sprintf("XP earned: %d", m.runXP()), m.levelLine()}
+	if m.levelled {
+		lines[1] += " — level up!"
+	}
+	for _, u := range m.opened {
+		lines = append(lines, "🔓 Unlocked "+u.Name)
+	}
+	return lines
+}
+
+// skin is the bow skin the archer is drawn with, if one is set and
+// unlocked
+func (m Game) skin() (bowSkin, bool) {
+	s, ok := bowSkins[m.cfg.BowSkin]
+	return s, ok && m.unlocked(profile.BowSkin, m.cfg.BowSkin)
+}
+
+// balloonColor is the color sprite s is drawn in: an unlocked balloon
+// pack's, or the theme's. The high-contrast theme keeps its own, which are
+// picked to stay apart under color blindness.
+func (m Game) balloonColor(s assets.Sprite) lipgloss.Color {
+	c, ok := balloonPacks[m.cfg.BalloonPack][s.Name]
+	if ok && m.unlocked(profile.BalloonPack, m.cfg.BalloonPack) && m.theme.Name != theme.HighContrast {
+		return c
+	}
+	return m.theme.Balloon(s.Name, s.Color)
+}
+
+// unlockedNames lists the IDs of kind the player can choose, after an
+// empty one for none
+func (m Game) unlockedNames(kind profile.Kind) []string {
+	names := []string{""}
+	for _, id := range profile.Names(kind) {
+		if m.unlocked(kind, id) {
+			names = append(names, id)
+		}
+	}
+	return names
+}
+
+// unlockLabel names a bow skin or balloon pack setting, or none for an
+// empty one
+func unlockLabel(kind profile.Kind, id, none string) string {
+	if u, ok := profile.Lookup(kind, id); ok {
+		return u.Name
+	}
+	return none
+}
@@ -0,0 +1,157 @@
+package game
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/ashX04/gobowarrow/internal/entities"
+)
+
+// arrowKind selects how an arrow behaves on impact
+type arrowKind = entities.ArrowKind
+
+const (
+	standardArrow arrowKind = iota
+	piercingArrow
+	splitArrow
+	bombArrow
+	arrowKindCount
+)
+
+// unlimitedAmmo marks an arrow kind that never runs out
+const unlimitedAmmo = -1
+
+// arrowSpec describes one kind of arrow in the quiver
+type arrowSpec struct {
+	name   string
+	icon   string
+	symbol string
+	ammo   int // starting ammo per run
+	refill int // ammo restored when a wave is cleared
+}
+
+var arrowSpecs = [arrowKindCount]arrowSpec{
+	standardArrow: {name: "Standard", icon: "→", symbol: "═>", ammo: unlimitedAmmo},
+	piercingArrow: {name: "Piercing", icon: "⇥", symbol: "═▷", ammo: 5, refill: 2},
+	splitArrow:    {name: "Split", icon: "⋔", symbol: "═≻", ammo: 5, refill: 2},
+	bombArrow:     {name: "Bomb", icon: "✹", symbol: "═●", ammo: 3, refill: 1},
+}
+
+// Per-kind tuning
+const (
+	piercingHits = 3    // extra balloons a piercing arrow passes through
+	splitSpread  = 0.15 // slope difference between split arrows
+	bombRadius   = 6.0  // cells around the impact a bomb pops
+)
+
+// reloadSeconds is how long refilling the quiver takes
+const reloadSeconds = 1.5
+
+// quiver holds the ammo left for each arrow kind
+type quiver [arrowKindCount]int
+
+// newQuiver fills the quiver with each kind's starting ammo
+func newQuiver() quiver {
+	var q quiver
+	for kind, spec := range arrowSpecs {
+		q[kind] = spec.ammo
+	}
+	return q
+}
+
+// has reports whether there is ammo left for kind
+func (q quiver) has(kind arrowKind) bool {
+	return q[kind] == unlimitedAmmo || q[kind] > 0
+}
+
+// take uses one arrow of kind
+func (q *quiver) take(kind arrowKind) {
+	if q[kind] != unlimitedAmmo {
+		q[kind]--
+	}
+}
+
+// refill restores some special ammo after a cleared wave
+func (q *quiver) refill() {
+	for kind, spec := range arrowSpecs {
+		if q[kind] != unlimitedAmmo {
+			q[kind] += spec.refill
+		}
+	}
+}
+
+// selectArrow switches to kind if there is ammo for it
+func (m *Game) selectArrow(kind arrowKind) {
+	if kind >= 0 && kind < arrowKindCount && m.quiver.has(kind) {
+		m.selected = kind
+	}
+}
+
+// quiverSize is the capacity for the current difficulty, with any bigger
+// quiver bought in the shop
+func (m Game) quiverSize() int {
+	return m.difficulty.QuiverSize(m.cfg.QuiverSize) + quiverStep*m.upgrades[biggerQuiver]
+}
+
+// startReload begins refilling the quiver unless it is full or already refilling
+func (m *Game) startReload() {
+	if m.reloadTicks > 0 || m.arrowsLeft == m.quiverSize() {
+		return
+	}
+	m.reloadTicks = int(reloadSeconds * float64(m.cfg.TickRate))
+	m.charging = false
+	m.charge = 0
+}
+
+// tickReload counts down a reload and refills the quiver when it finishes
+func (m *Game) tickReload() {
+	if m.reloadTicks == 0 {
+		return
+	}
+	m.reloadTicks--
+	if m.reloadTicks == 0 {
+		m.arrowsLeft = m.quiverSize()
+	}
+}
+
+// quiverView renders remaining arrows and the selectable arrow kinds, one
+// to a line beside the board
+func (m Game) quiverView(side bool) string {
+	selectedStyle := lipgloss.NewStyle().
+		Foreground(m.theme.Accent).
+		Bold(true)
+	normalStyle := lipgloss.NewStyle().Foreground(m.theme.Text)
+	emptyStyle := lipgloss.NewStyle().Foreground(m.theme.Faint)
+
+	slots := make([]string, arrowKindCount)
+	for kind, spec := range arrowSpecs {
+		count := "∞"
+		if m.quiver[kind] != unlimitedAmmo {
+			count = fmt.Sprint(m.quiver[kind])
+		}
+		label := fmt.Sprintf("%d:%s %s", kind+1, spec.icon, count)
+
+		switch {
+		case arrowKind(kind) == m.selected:
+			slots[kind] = selectedStyle.Render("[" + label + "]")
+		case !m.quiver.has(arrowKind(kind)):
+			slots[kind] = emptyStyle.Render(" " + label + " ")
+		default:
+			slots[kind] = normalStyle.Render(" " + label + " ")
+		}
+	}
+	countStyle := lipgloss.NewStyle().Foreground(m.theme.Score)
+	count := countStyle.Render(fmt.Sprintf("➶ %d/%d", m.arrowsLeft, m.quiverSize()))
+	if m.reloadTicks > 0 {
+		total := int(reloadSeconds * float64(m.cfg.TickRate))
+		done := float64(total-m.reloadTicks) / float64(total)
+		count = countStyle.Render("Reloading " + progressBar(8, done))
+	}
+
+	if side {
+		return lipgloss.JoinVertical(lipgloss.Left, append([]string{count}, slots...)...)
+	}
+	return count + "   " + strings.Join(slots, " ")
+}
@@ -0,0 +1,274 @@
+package game
+
+import (
+	"fmt"
+	"math/rand"
+	"slices"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/ashX04/gobowarrow/internal/anim"
+	"github.com/ashX04/gobowarrow/internal/assets"
+	"github.com/ashX04/gobowarrow/internal/savegame"
+)
+
+// countingSource is the run's rand.Source. It counts the values drawn from
+// it, so a saved run's RNG can be put back as its seed and that count.
+type countingSource struct {
+	rand.Source
+	draws uint64
+}
+
+func (s *countingSource) Int63() int64 {
+	s.draws++
+	return s.Source.Int63()
+}
+
+func (s *countingSource) Seed(seed int64) {
+	s.Source.Seed(seed)
+	s.draws = 0
+}
+
+// windTo draws and throws away values until n have been drawn in all
+func (s *countingSource) windTo(n uint64) {
+	for s.draws < n {
+		s.Int63()
+	}
+}
+
+// newRNG seeds a run's RNG, along with the source that counts its draws
+func newRNG(seed int64) (*rand.Rand, *countingSource) {
+	src := &countingSource{Source: rand.NewSource(seed)}
+	return rand.New(src), src
+}
+
+type runSavedMsg struct{ err error }
+
+// HangupMsg tells the game the process is being stopped from outside, such
+// as by its terminal closing. It saves the run if it can be resumed, then
+// quits.
+type HangupMsg struct{}
+
+// WithSavedRun offers r, a run quit part way through last time, from the
+// menu. Runs quit from now on are saved to path when it isn't empty.
+func (m Game) WithSavedRun(r *savegame.Run, path string) Game {
+	m.saved = r
+	m.savePath = path
+	if r != nil {
+		m.menuCursor = menuResume
+	}
+	return m
+}
+
+// CheckSave makes sure a saved run only names arrows, power-ups and math
+// operations the game has
+func CheckSave(r *savegame.Run) error {
+	return r.Check(savegame.Kinds{Arrows: int(arrowKindCount), PowerUps: int(effectKindCount), Ops: opCount})
+}
+
+// canSave reports whether quitting now keeps the run to resume. Levels,
+// networked matches and runs with plugin entities aren't kept, nor are
+// daily runs, which count as played once started, nor one changed from the
+// console, nor a run that's already over.
+func (m Game) canSave() bool {
+	return m.savePath != "" && m.level == nil && m.guest == nil && len(m.kinds) == 0 &&
+		!m.currentMode().daily && !m.cheated &&
+		(m.state == playing || m.state == paused) && m.downfall.ticks == 0
+}
+
+// quit leaves the game, saving the run first if it can be resumed
+func (m Game) quit() tea.Cmd {
+	return tea.Sequence(m.saveRun(), saveAchievements(m.achievements), tea.Quit)
+}
+
+// saveRun writes the run in progress to disk, if it can be resumed
+func (m Game) saveRun() tea.Cmd {
+	if !m.canSave() {
+		return nil
+	}
+	r, path := m.toSave(), m.savePath
+	return func() tea.Msg {
+		if err := r.Save(path); err != nil {
+			return runSavedMsg{err: fmt.Errorf("saved game: %w", err)}
+		}
+		return runSavedMsg{}
+	}
+}
+
+// savedMode is the index into modes of the saved run's mode, or -1 if
+// this build doesn't have it
+func savedMode(r *savegame.Run) int {
+	return slices.IndexFunc(modes, func(g gameMode) bool { return g.id == r.Mode })
+}
+
+// resume carries on the saved run and deletes it, so it can't be played
+// from the same point twice
+func (m Game) resume() (Game, tea.Cmd) {
+	r := m.saved
+	m.saved = nil
+	i := savedMode(r)
+	if i < 0 {
+		m.menuCursor = menuStart
+		return m, nil
+	}
+	// The run is set up as if it were starting with the saved seed and
+	// difficulty, without changing the player's own
+	player := m.playerCfg
+	m.mode = i
+	m.playerCfg.Seed, m.playerCfg.Difficulty = r.Seed, r.Difficulty
+	m = m.restart()
+	m.playerCfg = player
+	m.theme = m.runTheme(m.playerCfg.Theme, m.playerCfg.Themes)
+	m.fromSave(*r)
+
+	path := m.savePath
+	return m, tea.Batch(m.Init(), func() tea.Msg {
+		if err := savegame.Remove(path); err != nil {
+			return runSavedMsg{err: fmt.Errorf("saved game: %w", err)}
+		}
+		return runSavedMsg{}
+	})
+}
+
+// savedNote sums up the saved run for the menu
+func (m Game) savedNote() string {
+	r := m.saved
+	name := r.Mode
+	if i := savedMode(r); i >= 0 {
+		name = modes[i].name
+	}
+	return fmt.Sprintf("%s · wave %d · %d points", name, r.Wave.Number, r.Score)
+}
+
+// toSave captures the run for saving. Plugin entities are opaque to the
+// game, so they aren't kept.
+func (m Game) toSave() savegame.Run {
+	r := savegame.Run{
+		Mode:        m.currentMode().id,
+		Difficulty:  m.difficulty.Name,
+		Seed:        m.seed,
+		Draws:       m.source.draws,
+		Timer:       m.timer,
+		MovedAt:     m.movedAt,
+		ReloadTicks: m.reloadTicks,
+		HitStop:     m.juice.hitStop,
+		Banner:      m.banner,
+		BannerTicks: m.bannerTicks,
+		Score:       m.score,
+		Shots:       m.shots,
+		Hits:        m.hits,
+		Combo:       m.combo,
+		BestCombo:   m.bestCombo,
+		Strays:      m.strays,
+		Escaped:     m.escaped,
+		WaveEscaped: m.waveEscaped,
+		Lives:       m.lives,
+		Coins:       m.coins,
+		Upgrades:    m.upgrades[:],
+		Wave: savegame.Wave{
+			Number:  m.wave.number,
+			Quota:   m.wave.quota,
+			Spawned: m.wave.spawned,
+			Speed:   m.wave.speed,
+			Density: m.wave.density,
+		},
+		Archer:      m.archer,
+		ArcherCol:   m.archerCol,
+		Aim:         m.aim,
+		Selected:    int(m.selected),
+		Quiver:      m.quiver[:],
+		ArrowsLeft:  m.arrowsLeft,
+		Weather:     m.weather,
+		Arrows:      m.arrows,
+		Targets:     m.targets,
+		Typed:       m.typist.typed,
+		Typos:       m.typist.typos,
+		Record:      m.record,
+		GhostShot:   m.ghostShot,
+		GhostArrows: m.ghostArrows,
+	}
+	for _, e := range m.effects {
+		r.Effects = append(r.Effects, savegame.Effect{Kind: e.kind, Ticks: e.ticksLeft})
+	}
+	for _, b := range m.balloons {
+		r.Balloons = append(r.Balloons, savegame.Balloon{Balloon: b, Playback: b.Anim.Position()})
+	}
+	for _, b := range m.birds {
+		r.Birds = append(r.Birds, savegame.Bird{Bird: b, Playback: b.Anim.Position()})
+	}
+	if b := m.boss; b != nil {
+		r.Boss = &savegame.Boss{X: b.x, Y: b.y, DX: b.dx, DY: b.dy, HP: b.hp, FlashTicks: b.flashTicks}
+	}
+	if m.currentMode().math {
+		r.Problem = []int{m.problem.a, m.problem.b, m.problem.op}
+		r.Right, r.Wrong = m.card.right[:], m.card.wrong[:]
+	}
+	return r
+}
+
+// fromSave puts the saved run r back on a freshly started one
+func (m *Game) fromSave(r savegame.Run) {
+	m.rng, m.source = newRNG(r.Seed)
+	m.source.windTo(r.Draws)
+
+	m.timer, m.movedAt, m.reloadTicks = r.Timer, r.MovedAt, r.ReloadTicks
+	m.juice.hitStop = r.HitStop
+	m.banner, m.bannerTicks = r.Banner, r.BannerTicks
+	m.score, m.shots, m.hits = r.Score, r.Shots, r.Hits
+	m.combo, m.bestCombo, m.strays = r.Combo, r.BestCombo, r.Strays
+	m.escaped, m.waveEscaped, m.lives, m.coins = r.Escaped, r.WaveEscaped, r.Lives, r.Coins
+	copy(m.upgrades[:], r.Upgrades)
+	m.wave = wave{
+		number:  r.Wave.Number,
+		quota:   r.Wave.Quota,
+		spawned: r.Wave.Spawned,
+		speed:   r.Wave.Speed,
+		density: r.Wave.Density,
+	}
+
+	// The board may be smaller than the one the run was saved on
+	m.archer = min(r.Archer, m.height-1)
+	m.archerCol, m.aim = r.ArcherCol, r.Aim
+	m.selected = arrowKind(r.Selected)
+	copy(m.quiver[:], r.Quiver)
+	m.arrowsLeft = r.ArrowsLeft
+	for _, e := range r.Effects {
+		m.effects = append(m.effects, effect{kind: e.Kind, ticksLeft: e.Ticks})
+	}
+	m.weather = r.Weather
+
+	m.arrows = append(m.arrows[:0], r.Arrows...)
+	for _, s := range r.Balloons {
+		b := s.Balloon
+		a := balloonBob
+		if b.Popped {
+			a = assets.Explosion
+		}
+		b.Anim = anim.Resume(a, s.Playback)
+		m.balloons = append(m.balloons, b)
+	}
+	for _, s := range r.Birds {
+		b := s.Bird
+		b.Anim = anim.Resume(assets.BirdFlap, s.Playback)
+		m.birds = append(m.birds, b)
+	}
+	if b := r.Boss; b != nil {
+		m.boss = &boss{x: b.X, y: b.Y, dx: b.DX, dy: b.DY, hp: b.HP, flashTicks: b.FlashTicks}
+	}
+	// Targets stand where this board puts them; only the arrows in them
+	// carry over
+	for i := range min(len(m.targets), len(r.Targets)) {
+		m.targets[i].Stuck = r.Targets[i].Stuck
+	}
+
+	m.typist = typist{typed: r.Typed, typos: r.Typos}
+	if len(r.Problem) == 3 {
+		m.problem = problem{a: r.Problem[0], b: r.Problem[1], op: r.Problem[2]}
+	}
+	copy(m.card.right[:], r.Right)
+	copy(m.card.wrong[:], r.Wrong)
+
+	m.record = r.Record
+	m.ghostShot = r.GhostShot
+	m.ghostArrows = r.GhostArrows
+}
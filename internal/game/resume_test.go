@@ -0,0 +1,24 @@
+package game
+
+import "testing"
+
+func TestCheckSaveTakesRealRuns(t *testing.T) {
+	for _, mode := range []string{"classic", "math"} {
+		g := headless(t, mode, 5).Simulate(300)
+		g.selected = bombArrow
+		g.effects = append(g.effects, effect{kind: timeFreeze, ticksLeft: 10})
+		r := g.toSave()
+		if err := CheckSave(&r); err != nil {
+			t.Errorf("%s: CheckSave() = %v, want a real run let through", mode, err)
+		}
+	}
+}
+
+func TestCheckSaveTurnsDownUnknownKinds(t *testing.T) {
+	g := headless(t, "math", 5)
+	r := g.toSave()
+	r.Problem[2] = int(opCount)
+	if CheckSave(&r) == nil {
+		t.Error("CheckSave() let through a math operation the game doesn't have")
+	}
+}
@@ -0,0 +1,140 @@
+package game
+
+import (
+	"strings"
+
+	"github.com/ashX04/gobowarrow/internal/render"
+	"github.com/ashX04/gobowarrow/internal/theme"
+)
+
+// Scenery drift speeds. The hills are nearer than the clouds, so they
+// drift faster; the sun is far enough away to stay put.
+const (
+	cloudTicks = 8 // ticks per cell the clouds drift left
+	hillTicks  = 4 // ticks per cell the hills drift left
+)
+
+// Day and night tuning
+const (
+	daySteps      = 96   // times a day the sky's colors shift
+	sceneryTint   = 0.6  // how far the scenery takes on the sky's color
+	borderTint    = 0.4  // how far the board border does
+	maxStars      = 24   // stars out at midnight
+	moonDarkness  = 0.5  // darkness from which the moon is up instead of the sun
+	noonTimeOfDay = 0.25 // the sky when day_length turns days off
+)
+
+var (
+	sunArt = []string{
+		` \ | / `,
+		`-- O --`,
+		` / | \ `,
+	}
+	moonArt = []string{
+		`  .-.  `,
+		` (  (  `,
+		`  '-'  `,
+	}
+	cloudArt = []string{
+		`  .--.    `,
+		`.(    ).  `,
+		`(___.__)_)`,
+	}
+	// hillArt repeats along the bottom of the board, so its ends join up
+	hillArt = []string{
+		`      _.-""-._                  __                           `,
+		`__.-""        ""-.______.--""""  """--.____________.-"""-.___`,
+	}
+)
+
+// clouds places each cloud: how far along the sky it starts, as a
+// fraction, and its top row
+var clouds = []struct {
+	at  float64
+	row int
+}{{0.1, 1}, {0.45, 4}, {0.8, 0}}
+
+// drawScenery lays the backdrop down behind the action: a sun or the moon
+// and stars, clouds and hills, drawn faint so they never compete with the
+// balloons. Everything else draws over it. With reduced motion it holds
+// still.
+func (m Game) drawScenery(f *render.FrameBuffer) {
+	if !m.cfg.Scenery {
+		return
+	}
+	style := m.sceneryStyle()
+	drift := m.timer
+	if m.cfg.ReducedMotion {
+		drift = 0
+	}
+
+	// Stars come out one by one as the night deepens
+	dark := m.timeOfDay().Darkness()
+	sky := f.Height() - len(hillArt)
+	for i := range int(dark * maxStars) {
+		star := "."
+		if i%3 == 0 {
+			star = "+"
+		}
+		h := scatter(i)
+		f.Backdrop(int(h>>8)%sky, int(h>>16)%f.Width(), star, style)
+	}
+
+	orb := sunArt
+	if dark > moonDarkness {
+		orb = moonArt
+	}
+	for i, line := range orb {
+		f.Backdrop(i, f.Width()-len(orb[0])-2, line, style)
+	}
+
+	// Clouds drift off the left edge and come back in on the right
+	cloudWidth := len(cloudArt[len(cloudArt)-1])
+	span := f.Width() + cloudWidth
+	for _, c := range clouds {
+		x := (int(c.at*float64(span))-drift/cloudTicks)%span + span
+		x = x%span - cloudWidth
+		for i, line := range cloudArt {
+			f.Backdrop(c.row+i, x, line, style)
+		}
+	}
+
+	top := f.Height() - len(hillArt)
+	for i, line := range hillArt {
+		f.Backdrop(top+i, 0, repeatFrom(line, drift/hillTicks, f.Width()), style)
+	}
+}
+
+// scatter is a well-spread hash of i, for placing things so they look
+// random but land in the same places every time
+func scatter(i int) uint32 {
+	return uint32(i+1) * 2654435761
+}
+
+// repeatFrom is width characters of s repeated end to end, starting
+// offset characters in
+func repeatFrom(s string, offset, width int) string {
+	offset %= len(s)
+	line := strings.Repeat(s, (offset+width)/len(s)+1)
+	return line[offset : offset+width]
+}
+
+// timeOfDay is how far the run's day has got. Runs start at dawn, and
+// the sky moves on in steps so each shade of it is only styled once.
+func (m Game) timeOfDay() theme.TimeOfDay {
+	if m.cfg.DayLength == 0 {
+		return noonTimeOfDay
+	}
+	step := m.timer * daySteps / (m.cfg.DayLength * m.cfg.TickRate)
+	return theme.TimeOfDay(float64(step%daySteps) / daySteps)
+}
+
+// sceneryStyle is how the backdrop is drawn, in the sky's colors
+func (m Game) sceneryStyle() render.Style {
+	return render.Style{FG: m.timeOfDay().Tint(m.theme.Faint, sceneryTint), Faint: true}
+}
+
+// skyBorder is the board border's color, shaded by the time of day
+func (m Game) skyBorder() theme.Color {
+	return m.timeOfDay().Tint(m.theme.Border, borderTint)
+}
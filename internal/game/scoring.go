@@ -0,0 +1,94 @@
+package game
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/ashX04/gobowarrow/internal/entities"
+)
+
+// comboStep is how many consecutive hits raise the multiplier by one
+const comboStep = 3
+
+// maxMultiplier caps the combo multiplier
+const maxMultiplier = 5
+
+// longShotCells is how far an arrow has to fly for each point of the long
+// shot bonus
+const longShotCells = 20
+
+// multiplier is the score factor earned by the current combo
+func (m Game) multiplier() int {
+	return comboMultiplier(m.combo)
+}
+
+// comboMultiplier is the score factor a combo of that many hits earns
+func comboMultiplier(combo int) int {
+	return min(1+combo/comboStep, maxMultiplier)
+}
+
+// registerHit scores a hit by the host's arrows, or with guest the
+// guest's, and extends that archer's combo. It returns the base points,
+// the long shot bonus and the multiplier they were scored at. The host's
+// power-ups and feed are theirs alone.
+func (m *Game) registerHit(points, bonus int, guest bool) (int, int, int) {
+	if guest {
+		score, combo := m.tally(true)
+		multiplier := comboMultiplier(*combo)
+		*score += (points + bonus) * multiplier
+		*combo++
+		return points, bonus, multiplier
+	}
+	if m.hasEffect(scoreDoubler) {
+		points *= 2
+		bonus *= 2
+	}
+	multiplier := m.multiplier()
+	m.score += (points + bonus) * multiplier
+	m.combo++
+	m.bestCombo = max(m.bestCombo, m.combo)
+	if m.multiplier() > multiplier {
+		m.announce(fmt.Sprintf("Combo x%d!", m.multiplier()))
+	}
+	return points, bonus, multiplier
+}
+
+// scorePop scores a popped balloon for whoever popped it. Zen scores
+// nothing, and in math mode only the answer scores. Coins are the host's
+// alone.
+func (m *Game) scorePop(e event) {
+	b := e.balloon
+	x, y := b.Center()
+	if mode := m.currentMode(); mode.zen || mode.math && !m.checkAnswer(b.Word, x, y, e.guest) {
+		return
+	}
+	points, bonus, multiplier := m.registerHit(b.Points, e.bonus, e.guest)
+	m.addPopup(x, y, scorePopup(points, bonus, multiplier), b.Color)
+	if e.guest {
+		return
+	}
+	m.earnCoins(b.Golden)
+	if b.Golden {
+		m.announce(fmt.Sprintf("Popped golden balloon +%d", (points+bonus)*multiplier))
+	}
+}
+
+// countShots adds a volley to the arrows fired, for accuracy
+func (m *Game) countShots(e event) {
+	m.shots += len(e.volley)
+}
+
+// longShot is the bonus for arrow a popping a balloon where it is now: a
+// point for every longShotCells it is from the bow, so balloons picked off
+// as they come up on the far side are worth more
+func longShot(a entities.Arrow) int {
+	dx, dy := a.Body.Pos.X-a.Origin.X, a.Body.Pos.Y-a.Origin.Y
+	return int(math.Hypot(dx, dy*cellAspect) / longShotCells)
+}
+
+// registerMiss breaks the combo of whoever fired arrow a when it leaves
+// the board without hitting anything
+func (m *Game) registerMiss(a entities.Arrow) {
+	_, combo := m.tally(a.Guest)
+	*combo = 0
+}
@@ -0,0 +1,107 @@
+package game
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/ashX04/gobowarrow/internal/assets"
+	"github.com/ashX04/gobowarrow/internal/entities"
+	"github.com/ashX04/gobowarrow/internal/script"
+)
+
+// loadScript starts the level's script over for a new run. A script that
+// fails as it starts is reported and left out.
+func (m *Game) loadScript() {
+	m.script = nil
+	if m.level == nil || m.level.Source == "" {
+		return
+	}
+	s, err := script.Load(m.level.Script, m.level.Source, m.cfg.TickRate, scriptWorld{m})
+	if err != nil {
+		m.scriptFailed(err)
+		return
+	}
+	m.script = s
+}
+
+// tickScript runs the level's script for this tick
+func (m *Game) tickScript() {
+	if m.script == nil {
+		return
+	}
+	if err := m.script.Tick(scriptWorld{m}, m.timer); err != nil {
+		m.scriptFailed(err)
+	}
+}
+
+// scriptFailed stops a script that went wrong, and says why; the level
+// plays on without it
+func (m *Game) scriptFailed(err error) {
+	m.script = nil
+	m.notify("Level script stopped: "+err.Error(), m.theme.Danger)
+}
+
+// scriptLost reports whether the level's script has ended the run
+func (m Game) scriptLost() bool {
+	return m.script != nil && m.script.Lost()
+}
+
+// scriptWorld is the run as a level script sees it
+type scriptWorld struct{ m *Game }
+
+func (w scriptWorld) Spawn(kind string, count int) error {
+	m := w.m
+	var s assets.Sprite
+	if kind != "golden" && kind != "balloon" {
+		var ok bool
+		if s, ok = assets.Find(kind); !ok {
+			return fmt.Errorf("unknown sprite %q", kind)
+		}
+	}
+	for range min(max(count, 0), maxSpawn) {
+		var b entities.Balloon
+		switch kind {
+		case "golden":
+			b = m.newGoldenBalloon()
+		case "balloon":
+			art, color, marker := m.pickLevelBalloon()
+			b = m.newBalloon(art, color)
+			b.Marker = marker
+		default:
+			b = m.newBalloon(s.Art, m.theme.Balloon(s.Name, s.Color))
+			b.Marker = m.marker(s.Marker, s.Letter)
+		}
+		m.balloons = append(m.balloons, b)
+	}
+	return nil
+}
+
+func (w scriptWorld) Boss() {
+	if w.m.boss == nil {
+		w.m.spawnBoss()
+	}
+}
+
+func (w scriptWorld) BossHP() int {
+	if w.m.boss == nil {
+		return 0
+	}
+	return w.m.boss.hp
+}
+
+// Effect starts a power-up by its name in snake case, such as rapid_fire
+func (w scriptWorld) Effect(name string) error {
+	for kind, spec := range effectSpecs {
+		if spec.name != "" && strings.ReplaceAll(strings.ToLower(spec.name), " ", "_") == name {
+			w.m.addEffect(effectKind(kind))
+			return nil
+		}
+	}
+	return fmt.Errorf("unknown effect %q", name)
+}
+
+func (w scriptWorld) Banner(text string)  { w.m.showBanner(text) }
+func (w scriptWorld) Score() int          { return w.m.score }
+func (w scriptWorld) AddScore(points int) { w.m.score += points }
+func (w scriptWorld) Escaped() int        { return w.m.escaped }
+func (w scriptWorld) Random(n int) int    { return w.m.rng.Intn(n) + 1 }
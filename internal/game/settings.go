@@ -0,0 +1,395 @@
+package game
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/ashX04/gobowarrow/internal/config"
+	"github.com/ashX04/gobowarrow/internal/difficulty"
+	"github.com/ashX04/gobowarrow/internal/profile"
+	"github.com/ashX04/gobowarrow/internal/spritepack"
+	"github.com/ashX04/gobowarrow/internal/theme"
+)
+
+// Settings rows, in display order. One row per key binding starts at
+// rowKeys, and Save comes last.
+const (
+	rowDifficulty = iota
+	rowTickRate
+	rowFrameRate
+	rowRenderer
+	rowTheme
+	rowSprites
+	rowSkin
+	rowBalloons
+	rowScenery
+	rowAccessible
+	rowMotion
+	rowSound
+	rowMusic
+	rowMoveRepeat
+	rowName
+	rowKeys
+)
+
+// slider is a numeric setting changed in fixed steps with ←/→
+type slider struct {
+	lo, hi, step int
+}
+
+var (
+	tickRateSlider   = slider{lo: 5, hi: 60, step: 5}
+	frameRateSlider  = slider{lo: 15, hi: 120, step: 15}
+	moveRepeatSlider = slider{lo: 5, hi: 100, step: 5}
+	volumeSlider     = slider{lo: 0, hi: 100, step: 10}
+)
+
+// move steps v by dir steps, staying within the slider's range
+func (s slider) move(v, dir int) int {
+	return min(max(v+dir*s.step, s.lo), s.hi)
+}
+
+// bar draws v's position along the slider
+func (s slider) bar(v int) string {
+	return progressBar(12, float64(v-s.lo)/float64(s.hi-s.lo))
+}
+
+// settingsForm is the settings screen. Changes are made to a draft of the
+// player's config and only take effect when saved.
+type settingsForm struct {
+	draft   config.Config
+	cursor  int
+	from    int             // state to return to when the screen closes
+	name    textinput.Model // the player name while it's being edited
+	packs   []string        // sprite packs to choose from
+	skins   []string        // unlocked bow skins to choose from, after none
+	colors  []string        // unlocked balloon packs to choose from, after none
+	editing bool            // typing a player name
+	binding bool            // waiting for a key to bind
+	saved   bool
+	err     error // why the last save failed
+}
+
+// settingsSavedMsg reports the result of writing the config file
+type settingsSavedMsg struct{ err error }
+
+// openSettings shows the settings screen over the current state
+func (m Game) openSettings() Game {
+	name := textinput.New()
+	name.Placeholder = "anonymous"
+	name.CharLimit = config.MaxPlayerName
+	m.form = settingsForm{
+		draft:  m.playerCfg,
+		from:   m.state,
+		name:   name,
+		packs:  spritepack.Names(),
+		skins:  m.unlockedNames(profile.BowSkin),
+		colors: m.unlockedNames(profile.BalloonPack),
+	}
+	m.state = settings
+	return m
+}
+
+// saveRow is the index of the Save row
+func (f settingsForm) saveRow() int {
+	return rowKeys + len(f.draft.Keys.Bindings())
+}
+
+// updateSettings handles input on the settings screen
+func (m Game) updateSettings(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	f := &m.form
+	switch {
+	case msg.String() == "ctrl+c":
+		return m, tea.Sequence(saveAchievements(m.achievements), tea.Quit)
+
+	case f.binding:
+		// The next key pressed becomes the control's only key
+		if msg.String() != "esc" {
+			*f.draft.Keys.Bindings()[f.cursor-rowKeys].Keys = []string{keyName(msg)}
+			f.saved = false
+		}
+		f.binding = false
+		return m, nil
+
+	case f.editing:
+		switch msg.String() {
+		case "enter":
+			f.draft.PlayerName = strings.TrimSpace(f.name.Value())
+			f.saved = false
+		case "esc":
+		default:
+			var cmd tea.Cmd
+			f.name, cmd = f.name.Update(msg)
+			return m, cmd
+		}
+		f.editing = false
+		f.name.Blur()
+		return m, nil
+	}
+
+	rows := f.saveRow() + 1
+	switch msg.String() {
+	case "esc", "q":
+		// Leave without saving the draft
+		m.state = f.from
+	case "up", "k":
+		f.cursor = (f.cursor + rows - 1) % rows
+	case "down", "j":
+		f.cursor = (f.cursor + 1) % rows
+	case "left", "h":
+		f.adjust(-1)
+	case "right", "l":
+		f.adjust(1)
+	case "enter", " ":
+		switch {
+		case f.cursor == rowName:
+			f.editing = true
+			f.name.SetValue(f.draft.PlayerName)
+			f.name.CursorEnd()
+			return m, f.name.Focus()
+		case f.cursor >= rowKeys && f.cursor < f.saveRow():
+			f.binding = true
+		case f.cursor == f.saveRow():
+			return m.saveSettings()
+		default:
+			f.adjust(1)
+		}
+	}
+	return m, nil
+}
+
+// adjust cycles or slides the setting under the cursor
+func (f *settingsForm) adjust(dir int) {
+	d := &f.draft
+	switch f.cursor {
+	case rowDifficulty:
+		d.Difficulty = cycle(difficulty.Names(), d.Difficulty, dir)
+	case rowTickRate:
+		d.TickRate = tickRateSlider.move(d.TickRate, dir)
+	case rowFrameRate:
+		d.FrameRate = frameRateSlider.move(d.FrameRate, dir)
+	case rowRenderer:
+		d.Renderer = cycle(config.Renderers, d.Renderer, dir)
+	case rowTheme:
+		d.Theme = cycle(theme.Names(d.Themes), d.Theme, dir)
+	case rowSprites:
+		d.SpritePack = cycle(f.packs, d.SpritePack, dir)
+	case rowSkin:
+		d.BowSkin = cycle(f.skins, d.BowSkin, dir)
+	case rowBalloons:
+		d.BalloonPack = cycle(f.colors, d.BalloonPack, dir)
+	case rowScenery:
+		d.Scenery = !d.Scenery
+	case rowAccessible:
+		d.Accessible = !d.Accessible
+	case rowMotion:
+		d.ReducedMotion = !d.ReducedMotion
+	case rowSound:
+		d.Sound = cycle(config.Sounds, d.Sound, dir)
+	case rowMusic:
+		d.MusicVolume = volumeSlider.move(d.MusicVolume, dir)
+	case rowMoveRepeat:
+		d.MoveRepeat = float64(moveRepeatSlider.move(int(d.MoveRepeat), dir))
+	default:
+		return
+	}
+	f.saved = false
+}
+
+// apply copies the settings the screen edits onto c
+func (f settingsForm) apply(c *config.Config) {
+	c.Difficulty = f.draft.Difficulty
+	c.TickRate = f.draft.TickRate
+	c.FrameRate = f.draft.FrameRate
+	c.Renderer = f.draft.Renderer
+	c.Theme = f.draft.Theme
+	c.SpritePack = f.draft.SpritePack
+	c.BowSkin = f.draft.BowSkin
+	c.BalloonPack = f.draft.BalloonPack
+	c.Scenery = f.draft.Scenery
+	c.Accessible = f.draft.Accessible
+	c.ReducedMotion = f.draft.ReducedMotion
+	c.Sound = f.draft.Sound
+	c.MusicVolume = f.draft.MusicVolume
+	c.MoveRepeat = f.draft.MoveRepeat
+	c.PlayerName = f.draft.PlayerName
+	c.Keys = f.draft.Keys
+}
+
+// saveSettings adopts the draft and writes it to the config file. The
+// controls, colors and sprites change straight away; everything else
+// applies from the next run.
+func (m Game) saveSettings() (Game, tea.Cmd) {
+	f := &m.form
+	if err := f.draft.Validate(); err != nil {
+		f.err = err
+		return m, nil
+	}
+	var pack spritepack.Pack
+	if f.draft.SpritePack != "" {
+		var err error
+		if pack, err = spritepack.Open(f.draft.SpritePack); err != nil {
+			f.err = fmt.Errorf("sprite pack: %w", err)
+			return m, nil
+		}
+	}
+	f.err = nil
+	f.apply(&m.playerCfg)
+	m.cfg.Keys = f.draft.Keys
+	m.keys = newKeyMap(f.draft.Keys)
+	m.theme, _ = theme.Lookup(f.draft.Theme, f.draft.Themes)
+	if f.from == paused {
+		m.theme = m.runTheme(f.draft.Theme, f.draft.Themes)
+	}
+	m.cfg.BowSkin = f.draft.BowSkin
+	m.cfg.BalloonPack = f.draft.BalloonPack
+	m.cfg.Scenery = f.draft.Scenery
+	m.cfg.Accessible = f.draft.Accessible
+	m.cfg.ReducedMotion = f.draft.ReducedMotion
+	m.pack = pack
+	// Sound can be silenced straight away; turning it on waits for a restart
+	if f.draft.Sound == config.SoundOff {
+		m.sound = nil
+	}
+	if m.music != nil {
+		m.music.SetVolume(float64(f.draft.MusicVolume) / 100)
+	}
+	// A run in progress keeps the difficulty it started with
+	if f.from == menu && !m.currentMode().daily {
+		m.difficulty, _ = difficulty.Lookup(f.draft.Difficulty)
+	}
+
+	if m.configPath == "" {
+		f.saved = true
+		return m, nil
+	}
+	f.apply(&m.configFile)
+	path, file := m.configPath, m.configFile
+	return m, func() tea.Msg {
+		if err := file.Save(path); err != nil {
+			return settingsSavedMsg{err: fmt.Errorf("config: %w", err)}
+		}
+		return settingsSavedMsg{}
+	}
+}
+
+// settingsView renders the settings form
+func (m Game) settingsView() string {
+	headerStyle := lipgloss.NewStyle().
+		Foreground(m.theme.Title).
+		Bold(true).
+		MarginBottom(1)
+
+	itemStyle := lipgloss.NewStyle().
+		Foreground(m.theme.Text).
+		PaddingLeft(2)
+
+	selectedStyle := lipgloss.NewStyle().
+		Foreground(m.theme.Accent).
+		Bold(true)
+
+	hintStyle := lipgloss.NewStyle().
+		Foreground(m.theme.Muted).
+		MarginTop(1)
+
+	errStyle := lipgloss.NewStyle().
+		Foreground(m.theme.Danger).
+		MarginTop(1)
+
+	f := m.form
+	d := f.draft
+	name := d.PlayerName
+	if name == "" {
+		name = "anonymous"
+	}
+	if f.editing {
+		name = f.name.View()
+	}
+	labels := []string{
+		fmt.Sprintf("%-13s ◀ %s ▶", "Difficulty:", d.Difficulty),
+		fmt.Sprintf("%-13s %s %d/s", "Tick rate:", tickRateSlider.bar(d.TickRate), d.TickRate),
+		fmt.Sprintf("%-13s %s %d/s", "Frame rate:", frameRateSlider.bar(d.FrameRate), d.FrameRate),
+		fmt.Sprintf("%-13s ◀ %s ▶", "Renderer:", d.Renderer),
+		fmt.Sprintf("%-13s ◀ %s ▶", "Colors:", d.Theme),
+		fmt.Sprintf("%-13s ◀ %s ▶", "Sprites:", packLabel(d.SpritePack)),
+		fmt.Sprintf("%-13s ◀ %s ▶", "Bow:", unlockLabel(profile.BowSkin, d.BowSkin, "plain")),
+		fmt.Sprintf("%-13s ◀ %s ▶", "Balloons:", unlockLabel(profile.BalloonPack, d.BalloonPack, "theme colors")),
+		fmt.Sprintf("%-13s ◀ %s ▶", "Scenery:", onOff(d.Scenery)),
+		fmt.Sprintf("%-13s ◀ %s ▶", "Markers:", onOff(d.Accessible)),
+		fmt.Sprintf("%-13s ◀ %s ▶", "Calm effects:", onOff(d.ReducedMotion)),
+		fmt.Sprintf("%-13s ◀ %s ▶", "Sound:", d.Sound),
+		fmt.Sprintf("%-13s %s %d%%", "Music:", volumeSlider.bar(d.MusicVolume), d.MusicVolume),
+		fmt.Sprintf("%-13s %s %g/s", "Move speed:", moveRepeatSlider.bar(int(d.MoveRepeat)), d.MoveRepeat),
+		fmt.Sprintf("%-13s %s", "Player name:", name),
+	}
+	for i, b := range d.Keys.Bindings() {
+		keys := keyLabel(*b.Keys)
+		if f.binding && f.cursor == rowKeys+i {
+			keys = "press a key…"
+		}
+		labels = append(labels, fmt.Sprintf("%-13s %s", controlLabel(b.Name)+":", keys))
+	}
+	labels = append(labels, "Save")
+
+	items := make([]string, len(labels))
+	for i, label := range labels {
+		if i == f.cursor {
+			items[i] = selectedStyle.Render("▸ " + label)
+		} else {
+			items[i] = itemStyle.Render(label)
+		}
+	}
+
+	hint := "↑/↓ select, ←/→ change, ENTER edit, ESC back without saving"
+	switch {
+	case f.editing:
+		hint = "ENTER confirm, ESC cancel"
+	case f.binding:
+		hint = "Press the new key, ESC to keep the old one"
+	}
+	board := fmt.Sprintf("%dx%d", d.Width, d.Height)
+	if d.BoardScale > 0 {
+		board = fmt.Sprintf("%d%% of the terminal", d.BoardScale)
+	}
+	status := hintStyle.Render(fmt.Sprintf("Board %s, seed %s, leaderboard %s",
+		board, seedLabel(d.Seed), leaderboardLabel(d.LeaderboardURL)))
+	switch {
+	case f.err != nil:
+		status = errStyle.Render("Could not save: " + f.err.Error())
+	case f.saved:
+		status = hintStyle.Render("Saved. Controls, colors, sprites and volume change now, the rest from the next run")
+	}
+
+	return m.framedScreen(lipgloss.JoinVertical(
+		lipgloss.Left,
+		headerStyle.Render("SETTINGS"),
+		lipgloss.JoinVertical(lipgloss.Left, items...),
+		status,
+		hintStyle.UnsetMarginTop().Render(hint),
+	))
+}
+
+// onOff describes a toggle
+func onOff(on bool) string {
+	if on {
+		return "on"
+	}
+	return "off"
+}
+
+// packLabel names a sprite pack setting
+func packLabel(name string) string {
+	if name == "" {
+		return "classic"
+	}
+	return name
+}
+
+// controlLabel turns a [keys] name like "aim_up" into "Aim up"
+func controlLabel(name string) string {
+	return strings.ToUpper(name[:1]) + strings.ReplaceAll(name[1:], "_", " ")
+}
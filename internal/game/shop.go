@@ -0,0 +1,150 @@
+package game
+
+import (
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// Shop tuning
+const (
+	popCoins    = 1    // coins a popped balloon is worth
+	goldenCoins = 5    // coins a golden balloon is worth
+	maxUpgrade  = 3    // most times an upgrade can be bought in a run
+	speedStep   = 0.15 // arrow speed each level of faster arrows adds, as a fraction of the usual
+	quiverStep  = 3    // arrows each level of bigger quiver adds
+)
+
+// upgrade is something the shop sells
+type upgrade int
+
+const (
+	fasterArrows upgrade = iota
+	biggerQuiver
+	extraLife
+	widerPierce
+	upgradeCount
+)
+
+// upgradeSpec describes one of the shop's upgrades
+type upgradeSpec struct {
+	name  string
+	price int // coins for the first level; each level after costs this much more
+}
+
+var upgradeSpecs = [upgradeCount]upgradeSpec{
+	fasterArrows: {name: "Faster arrows", price: 8},
+	biggerQuiver: {name: "Bigger quiver", price: 6},
+	extraLife:    {name: "Extra life", price: 15},
+	widerPierce:  {name: "Wider pierce", price: 12},
+}
+
+// upgrades is how many levels of each upgrade the run has bought. They
+// last until the run ends.
+type upgrades [upgradeCount]int
+
+// hasShop reports whether the shop opens between this run's waves. Levels,
+// Zen and the practice range have no waves to shop between, and a
+// networked match would leave the other archer waiting.
+func (m Game) hasShop() bool {
+	mode := m.currentMode()
+	return m.level == nil && m.guest == nil && !mode.zen && !mode.targets
+}
+
+// sells reports whether the shop offers u in the current mode. An extra
+// life is only any use where escapes are limited, and Hardcore allows
+// none to spare.
+func (m Game) sells(u upgrade) bool {
+	mode := m.currentMode()
+	if u == extraLife {
+		return (mode.lives > 0 || mode.escapeLimit > 0) && !mode.oneMiss
+	}
+	return true
+}
+
+// upgradePrice is what the next level of u costs
+func (m Game) upgradePrice(u upgrade) int {
+	return upgradeSpecs[u].price * (m.upgrades[u] + 1)
+}
+
+// earnCoins pays out for popping a balloon
+func (m *Game) earnCoins(golden bool) {
+	if golden {
+		m.coins += goldenCoins
+	} else {
+		m.coins += popCoins
+	}
+}
+
+// openShop shows the shop over the board, listing each upgrade with its
+// level and price
+func (m *Game) openShop() {
+	lines := []string{fmt.Sprintf("Coins: %d", m.coins), ""}
+	for u := range upgradeCount {
+		spec := upgradeSpecs[u]
+		level := m.upgrades[u]
+		price := fmt.Sprintf("%d coins", m.upgradePrice(u))
+		switch {
+		case !m.sells(u):
+			price = "not sold here"
+		case level == maxUpgrade:
+			price = "sold out"
+		}
+		pips := strings.Repeat("●", level) + strings.Repeat("○", maxUpgrade-level)
+		lines = append(lines, fmt.Sprintf("%d  %-13s  %s  %13s", u+1, spec.name, pips, price))
+	}
+	m.dialog = dialog{
+		kind:  shopDialog,
+		title: fmt.Sprintf("Shop — wave %d cleared", m.wave.number-1),
+		lines: lines,
+		hint:  fmt.Sprintf("1-%d buy · ENTER next wave", upgradeCount),
+	}
+}
+
+// buy spends coins on the next level of u, if the run can have it and
+// afford it
+func (m *Game) buy(u upgrade) {
+	price := m.upgradePrice(u)
+	if !m.sells(u) || m.upgrades[u] == maxUpgrade || m.coins < price {
+		return
+	}
+	m.coins -= price
+	m.upgrades[u]++
+	switch u {
+	case biggerQuiver:
+		m.arrowsLeft += quiverStep
+	case extraLife:
+		if m.currentMode().lives > 0 {
+			m.lives++
+		}
+	}
+	m.announce(fmt.Sprintf("Bought %s", strings.ToLower(upgradeSpecs[u].name)))
+	m.openShop()
+}
+
+// updateShop handles a key press while the shop is open
+func (m Game) updateShop(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch key := msg.String(); {
+	case msg.Type == tea.KeyEnter || msg.Type == tea.KeyEsc:
+		m.dialog = dialog{}
+	case len(key) == 1 && key[0] >= '1' && key[0] < '1'+byte(upgradeCount):
+		m.buy(upgrade(key[0] - '1'))
+	}
+	return m, nil
+}
+
+// escapeLimit is how many escaped balloons end the run, allowing for
+// extra lives bought in the shop; 0 when escapes don't end it
+func (m Game) escapeLimit() int {
+	if limit := m.currentMode().escapeLimit; limit > 0 {
+		return limit + m.upgrades[extraLife]
+	}
+	return 0
+}
+
+// maxLives is how many lives the run can have, counting extra ones
+// bought in the shop
+func (m Game) maxLives() int {
+	return m.currentMode().lives + m.upgrades[extraLife]
+}
@@ -0,0 +1,19 @@
+package game
+
+import "github.com/ashX04/gobowarrow/internal/render"
+
+// RenderFrame returns what View would draw for g. It draws into a fresh
+// frame buffer and its own copy of the debug overlay's stats, so neither
+// g nor the frames View draws afterwards are touched.
+//
+// Colors come out however lipgloss's color profile says, which depends
+// on the terminal. Compare frames through render.StripANSI, which leaves
+// the same text whatever the profile.
+func RenderFrame(g Game) string {
+	g.frame = render.NewFrameBuffer(g.viewWidth(), g.height)
+	if g.debug != nil {
+		debug := *g.debug
+		g.debug = &debug
+	}
+	return g.View()
+}
@@ -0,0 +1,75 @@
+package game
+
+import (
+	"flag"
+	"os"
+	"path/filepath"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/ashX04/gobowarrow/internal/config"
+	"github.com/ashX04/gobowarrow/internal/render"
+)
+
+var update = flag.Bool("update", false, "rewrite the golden frames in testdata")
+
+// assertFrame compares g's frame, as plain text, with the golden file
+// testdata/frames/name.txt. Run the tests with -update to rewrite it.
+func assertFrame(t *testing.T, name string, g Game) {
+	t.Helper()
+	got := render.StripANSI(RenderFrame(g))
+	path := filepath.Join("testdata", "frames", name+".txt")
+	if *update {
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(got), 0o644); err != nil {
+			t.Fatal(err)
+		}
+		return
+	}
+	want, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got != string(want) {
+		t.Errorf("frame %s differs from %s; got:\n%s", name, path, got)
+	}
+}
+
+func TestFrames(t *testing.T) {
+	cfg := config.Default()
+	cfg.Seed = 42
+
+	t.Run("menu", func(t *testing.T) {
+		assertFrame(t, "menu", New(cfg))
+	})
+	t.Run("playing", func(t *testing.T) {
+		assertFrame(t, "playing", headless(t, "classic", 42).Simulate(60))
+	})
+	t.Run("paused", func(t *testing.T) {
+		g := headless(t, "classic", 42).Simulate(60)
+		m, _ := g.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'p'}})
+		if m.(Game).state != paused {
+			t.Fatal("p didn't pause the run")
+		}
+		assertFrame(t, "paused", m.(Game))
+	})
+	t.Run("zen", func(t *testing.T) {
+		assertFrame(t, "zen", headless(t, "zen", 3).Simulate(200))
+	})
+}
+
+func TestRenderFrameLeavesGameAlone(t *testing.T) {
+	g := headless(t, "classic", 42).Simulate(60)
+	g.toggleDebug()
+	frame := g.frame
+	RenderFrame(g)
+	if g.frame != frame {
+		t.Error("RenderFrame replaced the game's frame buffer")
+	}
+	if *g.debug != (debugStats{}) {
+		t.Errorf("RenderFrame wrote to the game's debug stats: %+v", *g.debug)
+	}
+}
@@ -0,0 +1,59 @@
+package game
+
+import (
+	"slices"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/ashX04/gobowarrow/internal/sound"
+)
+
+// playSound queues e to play with the next frame. Effects made several
+// times in one frame, like a bomb popping a cluster, play once.
+func (m *Game) playSound(e sound.Effect) {
+	if m.sound == nil || slices.Contains(m.sounds, e) {
+		return
+	}
+	m.sounds = append(m.sounds, e)
+}
+
+// popSound pops with each balloon
+func (m *Game) popSound(event) {
+	m.playSound(sound.Pop)
+}
+
+// shotSound twangs with each volley
+func (m *Game) shotSound(event) {
+	m.playSound(sound.Shoot)
+}
+
+// playSounds plays the queued effects off the Update goroutine, so a slow
+// terminal or audio device never holds up the game
+func (m *Game) playSounds() tea.Cmd {
+	if len(m.sounds) == 0 {
+		return nil
+	}
+	player, effects := m.sound, m.sounds
+	m.sounds = nil
+	return func() tea.Msg {
+		for _, e := range effects {
+			player.Play(e)
+		}
+		return nil
+	}
+}
+
+// syncMusic plays the music while a run is being played and pauses it
+// everywhere else, including the pause screen
+func (m *Game) syncMusic() {
+	on := m.state == playing && !m.dialog.open()
+	if m.music == nil || on == m.musicOn {
+		return
+	}
+	m.musicOn = on
+	if on {
+		m.music.Resume()
+	} else {
+		m.music.Pause()
+	}
+}
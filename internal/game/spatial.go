@@ -0,0 +1,60 @@
+package game
+
+import "github.com/ashX04/gobowarrow/internal/entities"
+
+// Spatial hash bucket size, in cells. A balloon spans a bucket or two
+// each way, so an arrow checks a handful of balloons however many are up.
+const (
+	bucketCols = 8
+	bucketRows = 4
+)
+
+// spatialHash is the broad phase of arrow collisions. It buckets the
+// balloons by every cell an arrow could strike them from, so each arrow
+// only checks the balloons in its own bucket. It's rebuilt every tick and
+// its buckets are reused from one tick to the next.
+type spatialHash struct {
+	cols, rows int
+	buckets    [][]int // indices into the balloons, in ascending order
+}
+
+// build buckets the unpopped balloons for a board width by height
+func (h *spatialHash) build(balloons []entities.Balloon, width, height int) {
+	h.cols, h.rows = width/bucketCols+1, height/bucketRows+1
+	n := h.cols * h.rows
+	if cap(h.buckets) < n {
+		h.buckets = make([][]int, n)
+	}
+	h.buckets = h.buckets[:n]
+	for i := range h.buckets {
+		h.buckets[i] = h.buckets[i][:0]
+	}
+
+	// Balloons go in in order, so every bucket stays sorted and arrows
+	// meet them in the same order as they would checking them all
+	for j, b := range balloons {
+		if b.Popped {
+			continue
+		}
+		// The cells struck reports a hit from
+		bx, by := b.Cell()
+		x0, y0 := max(bx-arrowReach, 0), max(by, 0)
+		x1, y1 := min(bx+b.Width, width-1), min(by+b.Height, height-1)
+		for r := y0 / bucketRows; r <= y1/bucketRows; r++ {
+			for c := x0 / bucketCols; c <= x1/bucketCols; c++ {
+				i := r*h.cols + c
+				h.buckets[i] = append(h.buckets[i], j)
+			}
+		}
+	}
+}
+
+// near returns the balloons an arrow in cell (x, y) might strike. Arrows
+// off the board can't strike any.
+func (h *spatialHash) near(x, y int) []int {
+	c, r := x/bucketCols, y/bucketRows
+	if x < 0 || y < 0 || c >= h.cols || r >= h.rows {
+		return nil
+	}
+	return h.buckets[r*h.cols+c]
+}
@@ -0,0 +1,106 @@
+package game
+
+import (
+	"fmt"
+	"math/rand"
+	"slices"
+	"testing"
+
+	"github.com/ashX04/gobowarrow/internal/assets"
+	"github.com/ashX04/gobowarrow/internal/entities"
+)
+
+// strewn puts n balloons of every size at random over g's board, some
+// cut loose and some already popped
+func strewn(g Game, n int, seed int64) []entities.Balloon {
+	rng := rand.New(rand.NewSource(seed))
+	var bs []entities.Balloon
+	for range n {
+		s := assets.Balloons[rng.Intn(len(assets.Balloons))]
+		b := g.newSizedBalloon(s, balloonSize(rng.Intn(3)))
+		b.Position = entities.At(rng.Float64()*float64(g.width), rng.Float64()*float64(g.height)-2)
+		b.Cut = rng.Intn(5) == 0
+		b.Popped = rng.Intn(10) == 0
+		bs = append(bs, b)
+	}
+	return bs
+}
+
+// naiveHits is every balloon an arrow in cell (x, y) strikes, found by
+// checking them all
+func naiveHits(bs []entities.Balloon, x, y int) []int {
+	var hits []int
+	for j, b := range bs {
+		if !b.Popped && struck(b, x, y) != missed {
+			hits = append(hits, j)
+		}
+	}
+	return hits
+}
+
+// hashedHits is every balloon an arrow in cell (x, y) strikes, found
+// through the spatial hash
+func hashedHits(h *spatialHash, bs []entities.Balloon, x, y int) []int {
+	var hits []int
+	for _, j := range h.near(x, y) {
+		if !bs[j].Popped && struck(bs[j], x, y) != missed {
+			hits = append(hits, j)
+		}
+	}
+	return hits
+}
+
+func TestSpatialHashFindsNaiveHits(t *testing.T) {
+	g := headless(t, "classic", 1)
+	for seed := int64(1); seed <= 20; seed++ {
+		bs := strewn(g, 200, seed)
+		// One balloon straddling a bucket corner, so it lands in four
+		edge := g.newBalloon(assets.Balloons[0].Art, "")
+		edge.Position = entities.At(bucketCols*2-2, bucketRows*2-1)
+		bs = append(bs, edge)
+
+		var h spatialHash
+		h.build(bs, g.width, g.height)
+		for x := range g.width {
+			for y := range g.height {
+				got, want := hashedHits(&h, bs, x, y), naiveHits(bs, x, y)
+				if !slices.Equal(got, want) {
+					t.Fatalf("seed %d, cell (%d, %d): hash found %v, want %v", seed, x, y, got, want)
+				}
+			}
+		}
+	}
+}
+
+// BenchmarkCollisions compares checking every balloon against every arrow
+// with going through the spatial hash, rebuilding it each time as a tick
+// does
+func BenchmarkCollisions(b *testing.B) {
+	g := headless(b, "classic", 1)
+	rng := rand.New(rand.NewSource(1))
+	arrows := make([][2]int, 20)
+	for i := range arrows {
+		arrows[i] = [2]int{rng.Intn(g.width), rng.Intn(g.height)}
+	}
+	for _, n := range []int{10, 100, 1000} {
+		bs := strewn(g, n, 1)
+		b.Run(fmt.Sprintf("naive/%d", n), func(b *testing.B) {
+			b.ResetTimer()
+			for range b.N {
+				for _, a := range arrows {
+					naiveHits(bs, a[0], a[1])
+				}
+			}
+		})
+		b.Run(fmt.Sprintf("hashed/%d", n), func(b *testing.B) {
+			var h spatialHash
+			b.ResetTimer()
+			for range b.N {
+				h.build(bs, g.width, g.height)
+				for _, a := range arrows {
+					hashedHits(&h, bs, a[0], a[1])
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,117 @@
+package game
+
+import (
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/ashX04/gobowarrow/internal/assets"
+	"github.com/ashX04/gobowarrow/internal/entities"
+)
+
+// Golden balloon tuning
+const (
+	goldenChance = 0.01 // chance any spawn is golden
+	goldenPoints = 10
+	goldenSpeed  = 2.0 // rises twice as fast as ordinary balloons
+)
+
+// newGoldenBalloon spawns the rare, fast, high-value golden balloon
+func (m Game) newGoldenBalloon() entities.Balloon {
+	b := m.newBalloon(assets.Golden, assets.Shimmer[0])
+	b.Golden = true
+	b.Points = goldenPoints
+	b.Speed = goldenSpeed
+	return b
+}
+
+// shimmer returns the golden balloon color for the current tick. With
+// reduced motion it holds steady.
+func (m Game) shimmer() lipgloss.Color {
+	if m.cfg.ReducedMotion {
+		return assets.Shimmer[0]
+	}
+	return assets.Shimmer[(m.timer/2)%len(assets.Shimmer)]
+}
+
+// balloonSize trades target size against rise speed and points
+type balloonSize int
+
+const (
+	smallBalloon balloonSize = iota
+	mediumBalloon
+	largeBalloon
+	balloonSizeCount
+)
+
+// sizeSpecs describe each size; medium balloons use the sprite's own art
+var sizeSpecs = [balloonSizeCount]struct {
+	name   string
+	points int
+	speed  float64
+	weight int // relative spawn frequency
+	art    []string
+}{
+	smallBalloon:  {name: "small", points: 5, speed: 1.5, weight: 2, art: assets.SmallBalloon},
+	mediumBalloon: {name: "medium", points: 2, speed: 1, weight: 5},
+	largeBalloon:  {name: "large", points: 1, speed: 0.6, weight: 3, art: assets.LargeBalloon},
+}
+
+// pickSize rolls a balloon size weighted by sizeSpecs
+func (m Game) pickSize() balloonSize {
+	total := 0
+	for _, s := range sizeSpecs {
+		total += s.weight
+	}
+	roll := m.rng.Intn(total)
+	for size, s := range sizeSpecs {
+		if roll < s.weight {
+			return balloonSize(size)
+		}
+		roll -= s.weight
+	}
+	return mediumBalloon
+}
+
+// balloonSprites are the balloons the waves spawn. The daily challenge
+// always uses the built-in ones so every player's balloons are the same
+// size.
+func (m Game) balloonSprites() []assets.Sprite {
+	if !m.packed() {
+		return assets.Balloons
+	}
+	return m.pack.Balloons
+}
+
+// packed reports whether the waves spawn a sprite pack's balloons
+func (m Game) packed() bool {
+	return len(m.pack.Balloons) > 0 && !m.currentMode().daily
+}
+
+// arrowSymbol is how an arrow of kind looks in flight. A sprite pack may
+// draw standard arrows its own way; special arrows keep their tips so they
+// can be told apart.
+func (m Game) arrowSymbol(kind arrowKind, charged bool) string {
+	switch {
+	case kind != standardArrow:
+		return arrowSpecs[kind].symbol
+	case m.pack.Arrow != "":
+		return m.pack.Arrow
+	case charged:
+		return "━━➤"
+	}
+	return arrowSpecs[kind].symbol
+}
+
+// newSizedBalloon spawns sprite s at the given size. A sprite pack's
+// balloons keep their own art at every size.
+func (m Game) newSizedBalloon(s assets.Sprite, size balloonSize) entities.Balloon {
+	spec := sizeSpecs[size]
+	art := spec.art
+	if art == nil || m.packed() {
+		art = s.Art
+	}
+	b := m.newBalloon(art, m.balloonColor(s))
+	b.Marker = m.marker(s.Marker, s.Letter)
+	b.Points = spec.points
+	b.Speed = spec.speed
+	return b
+}
@@ -0,0 +1,117 @@
+package game
+
+import (
+	"fmt"
+
+	"github.com/ashX04/gobowarrow/internal/entities"
+	"github.com/ashX04/gobowarrow/internal/render"
+	"github.com/ashX04/gobowarrow/internal/sound"
+)
+
+// rangeShots is how many arrows a round on the practice range gets
+const rangeShots = 15
+
+// zonePoints is what each of a target's zones scores
+var zonePoints = map[entities.Zone]int{
+	entities.Outer:    2,
+	entities.Inner:    5,
+	entities.Bullseye: 10,
+}
+
+// zoneNames are what a hit in each zone is called out as
+var zoneNames = map[entities.Zone]string{
+	entities.Outer:    "Outer",
+	entities.Inner:    "Inner",
+	entities.Bullseye: "Bullseye!",
+}
+
+// zoneArt is how each zone's row of a target is drawn. The rings fill in
+// toward the middle, so they read apart without color too.
+var zoneArt = map[entities.Zone]string{
+	entities.Outer:    "░░",
+	entities.Inner:    "▓▓",
+	entities.Bullseye: "██",
+}
+
+// placeTargets stands the practice range's targets at fixed distances: a
+// near one low down, one halfway and a far one up high
+func (m *Game) placeTargets() {
+	m.targets = nil
+	if !m.currentMode().targets {
+		return
+	}
+	reach := entities.TargetReach
+	for _, spot := range []struct{ across, down int }{{2, 3}, {3, 2}, {4, 1}} {
+		row := min(max(m.height*spot.down/4, reach), m.height-1-reach)
+		m.targets = append(m.targets, entities.Target{X: m.width * spot.across / 5, Y: row})
+	}
+}
+
+// hitTargets scores arrow a against each target it crossed this tick. An
+// arrow that hits one sticks in it, whatever kind it is.
+func (m *Game) hitTargets(a *entities.Arrow) {
+	for i := range m.targets {
+		t := &m.targets[i]
+		zone, row := t.Struck(a.Prev, a.Body.Pos)
+		if zone == entities.Missed {
+			continue
+		}
+		a.Active = false
+		m.countHit(a)
+		t.Stuck = append(t.Stuck, row)
+		points := zonePoints[zone]
+		score, _ := m.tally(a.Guest)
+		*score += points
+		m.playSound(sound.Pop)
+		m.addPopup(float64(t.X), float64(row)-1, fmt.Sprintf("+%d", points), "226")
+		m.announce(fmt.Sprintf("%s +%d", zoneNames[zone], points))
+		return
+	}
+}
+
+// rangeDone reports whether the round on the range is over: every arrow
+// fired and landed
+func (m Game) rangeDone() bool {
+	if m.shots < rangeShots {
+		return false
+	}
+	for _, a := range m.arrows {
+		if a.Active {
+			return false
+		}
+	}
+	return true
+}
+
+// zoneStyle is the color a target's zone is drawn in
+func (m Game) zoneStyle(zone entities.Zone) render.Style {
+	switch zone {
+	case entities.Bullseye:
+		return render.Style{FG: m.theme.Accent, Bold: true}
+	case entities.Inner:
+		return render.Style{FG: m.theme.Danger}
+	}
+	return render.Style{FG: m.theme.Text}
+}
+
+// drawTargets draws each target's rings with the arrows stuck in them
+// poking out toward the archer
+func (m Game) drawTargets(f *render.FrameBuffer, dim bool) {
+	for _, t := range m.targets {
+		for row := t.Y - entities.TargetReach; row <= t.Y+entities.TargetReach; row++ {
+			zone := t.ZoneAt(row)
+			style := m.zoneStyle(zone)
+			if dim {
+				style = m.dimStyle()
+			}
+			f.Text(row, t.X, zoneArt[zone], style)
+		}
+		style := render.Style{FG: m.theme.Muted}
+		if dim {
+			style = m.dimStyle()
+		}
+		for _, row := range t.Stuck {
+			f.Text(row, t.X-1, "→", style)
+		}
+	}
+}
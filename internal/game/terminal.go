@@ -0,0 +1,113 @@
+package game
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/ashX04/gobowarrow/internal/render"
+)
+
+// terminal is the size of the window the game is drawn in, as last
+// reported by Bubble Tea. It's zero until the first report, and headless
+// runs never get one.
+type terminal struct {
+	width, height int
+}
+
+// fits reports whether a screen width by height can be drawn without
+// wrapping or scrolling. An unknown size fits anything.
+func (t terminal) fits(width, height int) bool {
+	if t == (terminal{}) {
+		return true
+	}
+	return width <= t.width && height <= t.height
+}
+
+// playSize is how much room the play screen needs: the board with the
+// HUD panels currently showing around it, the title and the controls
+func (m Game) playSize() (width, height int) {
+	blank := strings.TrimSuffix(strings.Repeat(strings.Repeat(" ", m.viewWidth()+4)+"\n", m.height+2), "\n")
+	if hp := m.bossHPView(); hp != "" {
+		blank = lipgloss.JoinVertical(lipgloss.Center, hp, blank)
+	}
+	return lipgloss.Size(m.playScreen(m.layoutHUD(blank)))
+}
+
+// tooSmall reports whether the terminal is too small for the play screen.
+// The run holds until it's enlarged, then carries on by itself.
+func (m Game) tooSmall() bool {
+	return !m.term.fits(m.playSize())
+}
+
+// enlargeView asks for a bigger terminal in place of a screen that
+// wouldn't fit in it
+func (m Game) enlargeView(width, height int) string {
+	msgStyle := lipgloss.NewStyle().
+		Foreground(m.theme.Accent).
+		Bold(true).
+		Align(lipgloss.Center).
+		Width(m.term.width)
+	hintStyle := msgStyle.Foreground(m.theme.Muted).Bold(false)
+	return lipgloss.Place(
+		m.term.width, m.term.height,
+		lipgloss.Center, lipgloss.Center,
+		lipgloss.JoinVertical(
+			lipgloss.Center,
+			msgStyle.Render(fmt.Sprintf("Please enlarge your terminal to at least %dx%d", width, height)),
+			hintStyle.Render(fmt.Sprintf("It's %dx%d now", m.term.width, m.term.height)),
+		),
+	)
+}
+
+// Smallest board board_scale shrinks to, as config.Validate allows for
+// width and height
+const (
+	minBoardWidth  = 40
+	minBoardHeight = 10
+)
+
+// fitBoard sizes the board to fill board_scale percent of the terminal,
+// leaving what the title, controls and HUD need around it. Below the
+// smallest board it stops shrinking and lets the size guard take over.
+func (m *Game) fitBoard() {
+	scale := m.cfg.BoardScale
+	if scale == 0 || m.term == (terminal{}) {
+		return
+	}
+	// The HUD wraps to the board's width, so a second pass settles it
+	for range 2 {
+		playWidth, playHeight := m.playSize()
+		aroundWidth, aroundHeight := playWidth-(m.viewWidth()+4), playHeight-(m.height+2)
+		boxWidth := min(m.term.width*scale/100, m.term.width-aroundWidth)
+		boxHeight := min(m.term.height*scale/100, m.term.height-aroundHeight)
+		m.resize(max(boxWidth-2, minBoardWidth), max(boxHeight-2, minBoardHeight))
+	}
+}
+
+// resize changes the board to width by height, in the config's terms, and
+// moves the bounds that follow it, which span the level's whole world.
+// Balloons and the boss are kept inside the new bounds as they next move,
+// and arrows past them are dropped. The practice range's targets are set
+// up again to suit the new size.
+func (m *Game) resize(width, height int) {
+	m.cfg.Width, m.cfg.Height = width, height
+	m.frame = render.NewFrameBuffer(width-2, height) // Account for padding
+	// A level may stretch the world past the board's edge
+	if m.level != nil {
+		width = max(width, m.level.WorldWidth+2)
+	}
+	m.width, m.height = width-2, height
+	m.minBalloonX, m.maxBalloonX = (width-2)/2, width-7
+	m.camera.x = m.clampCamera(m.camera.x)
+	m.camera.prevX = m.camera.x
+	m.archer = min(m.archer, m.height-1)
+	if m.guest != nil {
+		m.guest.archer = min(m.guest.archer, m.height-1)
+	}
+	if m.level != nil {
+		m.tiles = newTileMap(m.level.Obstacles, m.width, m.height)
+	}
+	m.placeTargets()
+}
@@ -0,0 +1,113 @@
+package game
+
+import (
+	"math"
+
+	"github.com/ashX04/gobowarrow/internal/entities"
+	"github.com/ashX04/gobowarrow/internal/level"
+	"github.com/ashX04/gobowarrow/internal/render"
+)
+
+// tile is what fills one cell of the tile map
+type tile uint8
+
+const (
+	noTile tile = iota
+	wallTile
+	mirrorTile
+)
+
+// tileGlyphs are how the tiles are drawn
+var tileGlyphs = map[tile]string{wallTile: "█", mirrorTile: "▬"}
+
+// tileMap is a level's obstacles laid out as a grid the size of the board,
+// so the tile under an arrow is a lookup rather than a search. The zero
+// tileMap is empty.
+type tileMap struct {
+	width, height int
+	tiles         []tile
+}
+
+// newTileMap lays obstacles onto a width by height board, clipping any
+// that run off it
+func newTileMap(obstacles []level.Obstacle, width, height int) tileMap {
+	if len(obstacles) == 0 {
+		return tileMap{}
+	}
+	t := tileMap{width: width, height: height, tiles: make([]tile, width*height)}
+	for _, o := range obstacles {
+		kind := wallTile
+		if o.Kind == level.ObstacleMirror {
+			kind = mirrorTile
+		}
+		for y := o.Y; y < min(o.Y+o.Height, height); y++ {
+			for x := o.X; x < min(o.X+o.Width, width); x++ {
+				t.tiles[y*width+x] = kind
+			}
+		}
+	}
+	return t
+}
+
+// at is the tile in cell (x, y); there are none off the board
+func (t tileMap) at(x, y int) tile {
+	if x < 0 || y < 0 || x >= t.width || y >= t.height {
+		return noTile
+	}
+	return t.tiles[y*t.width+x]
+}
+
+// collideTiles walks the path arrow a flew this tick a cell at a time, so
+// a fast arrow can't skip over a thin wall. A wall stops the arrow. A
+// mirror it drops or climbs onto turns it back the other way vertically;
+// one it flies into side-on stops it like a wall.
+func (m *Game) collideTiles(a *entities.Arrow) {
+	if m.tiles.tiles == nil {
+		return
+	}
+	from, to := a.Prev, a.Body.Pos
+	path := to.Add(from.Scale(-1))
+	steps := int(math.Ceil(max(math.Abs(path.X), math.Abs(path.Y))))
+	last := from
+	_, lastY := from.Cell()
+	for s := 1; s <= steps; s++ {
+		p := from.Add(path.Scale(float64(s) / float64(steps)))
+		x, y := p.Cell()
+		switch m.tiles.at(x, y) {
+		case noTile:
+			last, lastY = p, y
+			continue
+		case mirrorTile:
+			if y != lastY {
+				a.Body.Pos = last
+				a.Body.Vel.Y = -a.Body.Vel.Y
+				return
+			}
+		}
+		a.Body.Pos = last
+		a.Active = false
+		if !a.Hit {
+			m.registerMiss(*a)
+		}
+		return
+	}
+}
+
+// drawTiles draws the level's obstacles. They sit behind the balloons,
+// which float past them, but in front of the scenery.
+func (m Game) drawTiles(f *render.FrameBuffer, dim bool) {
+	styles := map[tile]render.Style{
+		wallTile:   {FG: m.theme.Muted},
+		mirrorTile: {FG: m.theme.Accent, Bold: true},
+	}
+	for i, t := range m.tiles.tiles {
+		if t == noTile {
+			continue
+		}
+		style := styles[t]
+		if dim {
+			style = m.dimStyle()
+		}
+		f.Set(i%m.tiles.width, i/m.tiles.width, tileGlyphs[t], style)
+	}
+}
@@ -0,0 +1,58 @@
+package game
+
+import (
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/ashX04/gobowarrow/internal/render"
+)
+
+// Toast timing
+const (
+	toastSeconds = 3 // how long each toast stays on screen
+	toastSlide   = 3 // ticks a toast takes to slide in, and again to slide out
+)
+
+// toast is a notification waiting its turn in the top-right corner
+type toast struct {
+	text  string
+	color lipgloss.TerminalColor // its background
+}
+
+// notify queues a toast. Toasts show one at a time, in the order they
+// came, each for toastSeconds.
+func (m *Game) notify(text string, color lipgloss.TerminalColor) {
+	m.toasts = append(m.toasts, toast{text: text, color: color})
+}
+
+// tickToasts expires the front toast once it has been shown long enough
+func (m *Game) tickToasts() {
+	if len(m.toasts) == 0 {
+		return
+	}
+	m.toastTicks++
+	if m.toastTicks >= toastSeconds*m.cfg.TickRate {
+		m.toasts = m.toasts[1:]
+		m.toastTicks = 0
+	}
+}
+
+// drawToast renders the front toast in the top-right corner of the board.
+// It slides in from the right edge and back out again as it expires; with
+// reduced motion it just appears.
+func (m Game) drawToast(f *render.FrameBuffer) {
+	if len(m.toasts) == 0 {
+		return
+	}
+	t := m.toasts[0]
+	toastStyle := render.Style{FG: m.theme.Contrast, BG: t.color, Bold: true}
+
+	text := " " + t.text + " "
+	width := lipgloss.Width(text)
+	x := max(f.Width()-width, 0)
+	// How far in it has come, in ticks
+	in := min(m.toastTicks+1, toastSeconds*m.cfg.TickRate-m.toastTicks, toastSlide)
+	if !m.cfg.ReducedMotion && in < toastSlide {
+		x += width * (toastSlide - in) / toastSlide
+	}
+	f.Text(0, x, text, toastStyle)
+}
@@ -0,0 +1,52 @@
+package game
+
+import "fmt"
+
+// movingWindow is how long after the archer last moved, in seconds, that
+// they still count as on the move
+const movingWindow = 0.5
+
+// trickEvent is what the trick shot rules judge about an arrow popping a
+// balloon
+type trickEvent struct {
+	pops    int  // balloons the arrow has popped, this one included
+	lastRow bool // the balloon was on the top row, about to escape
+	moving  bool // the archer who fired it was on the move
+}
+
+// trickShot is a named feat with the bonus it earns
+type trickShot struct {
+	name  string
+	bonus int
+	check func(ev trickEvent) bool
+}
+
+// trickShots lists every feat, in the order they're announced
+var trickShots = []trickShot{
+	{name: "Skewer", bonus: 5, check: func(ev trickEvent) bool { return ev.pops == 2 }},
+	{name: "Last Gasp", bonus: 3, check: func(ev trickEvent) bool { return ev.lastRow }},
+	{name: "Run and Gun", bonus: 2, check: func(ev trickEvent) bool { return ev.moving }},
+}
+
+// judgeTricks awards the bonus for every feat a balloon popped by one of
+// the host's arrows pulls off, and puts each one in the event feed. Blasts
+// and chain reactions aren't shots, and zen keeps no score, so none of
+// those are judged.
+func (m *Game) judgeTricks(e event) {
+	if e.arrow == nil || e.guest || m.currentMode().zen {
+		return
+	}
+	_, row := e.balloon.Cell()
+	ev := trickEvent{pops: e.arrow.Pops, lastRow: row == 0, moving: m.moving()}
+	for _, t := range trickShots {
+		if t.check(ev) {
+			m.score += t.bonus
+			m.announce(fmt.Sprintf("🎯 %s +%d", t.name, t.bonus))
+		}
+	}
+}
+
+// moving reports whether the archer has moved within movingWindow
+func (m Game) moving() bool {
+	return m.movedAt > 0 && m.timer-m.movedAt < int(movingWindow*float64(m.cfg.TickRate))
+}
@@ -0,0 +1,182 @@
+package game
+
+import (
+	"math"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/ashX04/gobowarrow/internal/entities"
+	"github.com/ashX04/gobowarrow/internal/render"
+)
+
+// Typing mode's auto-aim tries every launch slope this far either side of
+// level, this far apart
+const (
+	typingMaxSlope  = 2.0
+	typingSlopeStep = 0.01
+)
+
+// typingCharge is how far the bow is drawn for a typed shot: all the way,
+// so the arrow can catch balloons on their way up the board
+const typingCharge = 1.0
+
+// typingTries is how many words are drawn for a balloon looking for one
+// that starts with a letter no other balloon's word does
+const typingTries = 8
+
+// typingWords are the words typing mode's balloons carry: short and
+// common, all lower case
+var typingWords = []string{
+	"arc", "arrow", "aim", "bow", "bird", "bolt", "cloud", "calm", "draw",
+	"dart", "drift", "echo", "east", "float", "fly", "gust", "glide",
+	"hill", "high", "ice", "jump", "just", "kite", "keen", "lift", "loft",
+	"mist", "moon", "nock", "north", "oak", "open", "pop", "puff", "quick",
+	"quiet", "rise", "rain", "sky", "string", "sun", "tip", "true", "up",
+	"under", "vane", "view", "wind", "wave", "yew", "yard", "zest", "zip",
+}
+
+// typist is typing mode's keyboard: the word being typed and how often
+// the player slipped
+type typist struct {
+	typed string // letters so far toward some balloon's word
+	typos int    // letters that led to no balloon's word
+}
+
+// tickTyping labels new balloons with words, forgets a word whose balloon
+// has gone, and shoots at a balloon typed in full once the bow is ready
+func (m *Game) tickTyping() {
+	if !m.currentMode().typing {
+		return
+	}
+	m.labelWords()
+	if m.typist.typed != "" && m.wordTarget(m.typist.typed, false) < 0 {
+		m.typist.typed = ""
+	}
+	m.fireTyped()
+}
+
+// labelWords gives each balloon without a word one. Words start with
+// different letters where the list allows, so a word's first letter is
+// enough to pick its balloon.
+func (m *Game) labelWords() {
+	used := map[byte]bool{}
+	for _, b := range m.balloons {
+		if b.Word != "" && !b.Popped {
+			used[b.Word[0]] = true
+		}
+	}
+	for i := range m.balloons {
+		b := &m.balloons[i]
+		if b.Word != "" || b.Popped {
+			continue
+		}
+		for range typingTries {
+			b.Word = typingWords[m.rng.Intn(len(typingWords))]
+			if !used[b.Word[0]] {
+				break
+			}
+		}
+		used[b.Word[0]] = true
+	}
+}
+
+// typeKey handles a key press in typing mode, reporting whether it was
+// typing. A letter that leads to no balloon's word is a typo and starts
+// the word over from that letter.
+func (m *Game) typeKey(msg tea.KeyMsg) bool {
+	switch {
+	case msg.Type == tea.KeyBackspace:
+		if n := len(m.typist.typed); n > 0 {
+			m.typist.typed = m.typist.typed[:n-1]
+		}
+		return true
+	case msg.Type != tea.KeyRunes || len(msg.Runes) != 1:
+		return false
+	}
+	letter := strings.ToLower(string(msg.Runes[0]))
+	if letter < "a" || letter > "z" {
+		return false
+	}
+	typed := m.typist.typed + letter
+	if m.wordTarget(typed, false) < 0 {
+		m.typist.typos++
+		typed = letter
+		if m.wordTarget(typed, false) < 0 {
+			typed = ""
+		}
+	}
+	m.typist.typed = typed
+	m.fireTyped()
+	return true
+}
+
+// wordTarget is the index of a live balloon whose word starts with typed,
+// or is typed if whole is set, or -1 if there isn't one
+func (m Game) wordTarget(typed string, whole bool) int {
+	for i, b := range m.balloons {
+		if b.Popped || b.Cut || !strings.HasPrefix(b.Word, typed) {
+			continue
+		}
+		if !whole || b.Word == typed {
+			return i
+		}
+	}
+	return -1
+}
+
+// fireTyped shoots at the balloon whose word has been typed in full. If
+// the bow isn't ready the word stays typed, and it goes once it is.
+func (m *Game) fireTyped() {
+	if m.typist.typed == "" {
+		return
+	}
+	i := m.wordTarget(m.typist.typed, true)
+	if i < 0 {
+		return
+	}
+	shots := m.shots
+	m.fireArrow(typingCharge, m.aimAt(m.balloons[i]))
+	if m.shots > shots {
+		m.typist.typed = ""
+	}
+}
+
+// aimAt finds the launch slope whose flight passes closest to the middle
+// of b's body, allowing for how far it rises while the arrow is on the way. Its
+// sideways wobble can still make the arrow miss.
+func (m Game) aimAt(b entities.Balloon) float64 {
+	x, _ := b.Center()
+	y := b.Y + float64(b.BodyHeight())/2
+	dt, rise := m.dt(), m.riseSpeed()*b.Speed
+	ground := float64(m.height - 1)
+	best, closest := 0.0, math.Inf(1)
+	for slope := -typingMaxSlope; slope <= typingMaxSlope; slope += typingSlopeStep {
+		shot := m.newArrow(typingCharge, slope).Body
+		t := 0.0
+		for ; shot.Pos.X < x && shot.Pos.Y < ground && t < botFlight; t += dt {
+			shot.Step(m.cfg.Gravity, dt)
+		}
+		// A balloon still coming up from below is met at the bottom row
+		if miss := math.Abs(shot.Pos.Y - min(y-rise*t, ground)); shot.Pos.X >= x && miss < closest {
+			best, closest = slope, miss
+		}
+	}
+	return best
+}
+
+// drawWord writes b's word on the row above it, with whatever has been
+// typed toward it picked out
+func (m Game) drawWord(f *render.FrameBuffer, b entities.Balloon, x, y int, dim bool) {
+	style, typedStyle := m.labelStyle(), render.Style{FG: m.theme.Accent, Bold: true}
+	if dim {
+		style, typedStyle = m.dimStyle(), m.dimStyle()
+	}
+	col := x + (b.Width-len(b.Word))/2
+	typed := ""
+	if m.typist.typed != "" && strings.HasPrefix(b.Word, m.typist.typed) {
+		typed = m.typist.typed
+	}
+	f.Text(y-1, col, typed, typedStyle)
+	f.Text(y-1, col+len(typed), b.Word[len(typed):], style)
+}
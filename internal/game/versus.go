@@ -0,0 +1,316 @@
+package game
+
+import (
+	"fmt"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/ashX04/gobowarrow/internal/config"
+	"github.com/ashX04/gobowarrow/internal/render"
+)
+
+// versusTarget is the score that wins a versus race
+const versusTarget = 50
+
+// versusMinWidth is the narrowest board the config accepts
+const versusMinWidth = 40
+
+// versusKeys maps each player's keys onto the single-player controls
+var versusKeys = [2]map[string]string{
+	{
+		"w": "up", "s": "down", "a": "w", "d": "s", " ": " ", "r": "r",
+		"1": "1", "2": "2", "3": "3", "4": "4",
+	},
+	{
+		"up": "up", "down": "down", "left": "w", "right": "s", "enter": " ", "/": "r",
+		"7": "1", "8": "2", "9": "3", "0": "4",
+	},
+}
+
+// Versus races two players on side-by-side boards. Both boards share a
+// seed, so each player faces exactly the same balloons.
+type Versus struct {
+	cfg      config.Config
+	players  [2]Game
+	finished [2]int // tick each player hit the target or ran out; 0 while racing
+	over     bool
+	bot      *bot // plays the second board; nil when two people are playing
+}
+
+// NewVersus sets up a race from cfg, halving the board width so both
+// boards fit side by side
+func NewVersus(cfg config.Config) Versus {
+	seeded := cfg
+	if seeded.Seed == 0 {
+		seeded.Seed = time.Now().UnixNano()
+	}
+	seeded.Width = max(cfg.Width/2, versusMinWidth)
+	// Both players share one keyboard, so the split controls stay fixed
+	seeded.Keys = config.DefaultKeys()
+	// Panels beside a board would push the two apart, so they go below
+	for _, p := range seeded.HUD.Panels() {
+		if *p.Place == config.PanelLeft || *p.Place == config.PanelRight {
+			*p.Place = config.PanelBottom
+		}
+	}
+
+	v := Versus{cfg: cfg}
+	for i := range v.players {
+		v.players[i] = New(seeded).Start()
+	}
+	return v
+}
+
+// NewBotMatch races the player against the computer, whose board is shown
+// mirrored on the right so the two archers face each other
+func NewBotMatch(cfg config.Config) Versus {
+	v := NewVersus(cfg)
+	v.players[1].frame.Mirror()
+	v.bot = newBot(cfg, v.players[1].seed)
+	return v
+}
+
+func (v Versus) Init() tea.Cmd {
+	return frame(v.cfg)
+}
+
+// Update routes each player's keys to their own board and advances both
+// boards off a single frame loop
+func (v Versus) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case HangupMsg:
+		return v, tea.Quit
+	case tea.KeyMsg:
+		key := msg.String()
+		switch {
+		case key == "q" || key == "ctrl+c":
+			return v, tea.Quit
+		case v.over && key == "r":
+			// A rematch gets fresh balloons unless the seed was fixed
+			if v.bot != nil {
+				v = NewBotMatch(v.cfg)
+			} else {
+				v = NewVersus(v.cfg)
+			}
+			return v, v.Init()
+		case v.over:
+			return v, nil
+		case key == "ctrl+z":
+			for i := range v.players {
+				v.players[i] = v.press(i, msg)
+			}
+			return v, tea.Suspend
+		case key == "p":
+			for i := range v.players {
+				v.players[i] = v.press(i, msg)
+			}
+			return v, nil
+		}
+		for i, keys := range versusKeys {
+			if i == 1 && v.bot != nil {
+				break
+			}
+			if mapped, ok := keys[key]; ok && v.racing(i) {
+				v.players[i] = v.press(i, keyMsg(mapped))
+			}
+		}
+
+	case frameMsg:
+		if v.over {
+			return v, nil
+		}
+		if v.bot != nil && v.racing(1) {
+			if key, ok := v.bot.think(v.players[1], time.Time(msg)); ok {
+				v.players[1] = v.press(1, keyMsg(key))
+			}
+		}
+		for i := range v.players {
+			if v.racing(i) {
+				// Players have nothing to save, so their commands are dropped
+				next, _ := v.players[i].advance(time.Time(msg))
+				v.players[i] = next.(Game)
+			}
+			p := v.players[i]
+			if v.finished[i] == 0 && (p.score >= versusTarget || !p.live()) {
+				v.finished[i] = max(p.timer, 1)
+			}
+		}
+		v.over = v.winner() >= 0 || (v.finished[0] > 0 && v.finished[1] > 0)
+		if v.over {
+			return v, nil
+		}
+		return v, frame(v.cfg)
+	}
+	return v, nil
+}
+
+// press sends a key to player i
+func (v Versus) press(i int, msg tea.KeyMsg) Game {
+	next, _ := v.players[i].Update(msg)
+	return next.(Game)
+}
+
+// racing reports whether player i is still going
+func (v Versus) racing(i int) bool {
+	return v.finished[i] == 0
+}
+
+// live reports whether the run is still being played
+func (m Game) live() bool {
+	return m.state == playing || m.state == paused
+}
+
+// winner returns the winning player, or -1 if there isn't one yet or the
+// race was drawn. Reaching the target first wins; if neither player does,
+// the higher score wins once both runs are over.
+func (v Versus) winner() int {
+	reached := [2]bool{}
+	for i, p := range v.players {
+		reached[i] = p.score >= versusTarget
+	}
+	switch {
+	case reached[0] && reached[1]:
+		switch {
+		case v.finished[0] < v.finished[1]:
+			return 0
+		case v.finished[1] < v.finished[0]:
+			return 1
+		}
+	case reached[0]:
+		return 0
+	case reached[1]:
+		return 1
+	case v.finished[0] > 0 && v.finished[1] > 0:
+		switch {
+		case v.players[0].score > v.players[1].score:
+			return 0
+		case v.players[1].score > v.players[0].score:
+			return 1
+		}
+	}
+	return -1
+}
+
+// name is what player i is called on screen
+func (v Versus) name(i int) string {
+	if i == 1 && v.bot != nil {
+		return "Computer"
+	}
+	return fmt.Sprintf("Player %d", i+1)
+}
+
+// keyMsg builds the key press the single-player controls expect
+func keyMsg(key string) tea.KeyMsg {
+	switch key {
+	case "up":
+		return tea.KeyMsg{Type: tea.KeyUp}
+	case "down":
+		return tea.KeyMsg{Type: tea.KeyDown}
+	case " ":
+		return tea.KeyMsg{Type: tea.KeySpace, Runes: []rune{' '}}
+	}
+	return tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(key)}
+}
+
+// View shows both boards, or the result once the race is over
+func (v Versus) View() string {
+	if v.players[0].cfg.ASCII {
+		return render.ASCII(v.view())
+	}
+	return v.view()
+}
+
+// view renders the match
+func (v Versus) view() string {
+	if v.over {
+		return v.resultView()
+	}
+	t := v.players[0].theme
+
+	titleStyle := lipgloss.NewStyle().
+		Foreground(t.Title).
+		Bold(true).
+		MarginBottom(1)
+
+	labelStyle := lipgloss.NewStyle().
+		Foreground(t.Accent).
+		Bold(true)
+
+	controlsStyle := lipgloss.NewStyle().
+		Foreground(t.Muted).
+		MarginTop(1)
+
+	boards := make([]string, len(v.players))
+	for i, p := range v.players {
+		label := v.name(i)
+		if !v.racing(i) {
+			label += " — out"
+		}
+		boards[i] = lipgloss.JoinVertical(lipgloss.Center, labelStyle.Render(label), p.boardView())
+	}
+
+	controls := "P1: w/s move, a/d aim, 1-4 arrow, SPACE shoot, r reload\n" +
+		"P2: ↑/↓ move, ←/→ aim, 7-0 arrow, ENTER shoot, / reload\n"
+	if v.bot != nil {
+		controls = "w/s move, a/d aim, 1-4 arrow, SPACE shoot, r reload\n"
+	}
+	return lipgloss.JoinVertical(
+		lipgloss.Center,
+		titleStyle.Render(fmt.Sprintf("🎯 Versus: first to %d 🎯", versusTarget)),
+		lipgloss.JoinHorizontal(lipgloss.Top, boards[0], "  ", boards[1]),
+		controlsStyle.Render(controls+"p pause, q quit"),
+	)
+}
+
+// resultView compares both players once the race is decided
+func (v Versus) resultView() string {
+	t := v.players[0].theme
+	titleStyle := lipgloss.NewStyle().
+		Foreground(t.Accent).
+		Bold(true).
+		MarginBottom(1)
+
+	statsStyle := lipgloss.NewStyle().
+		Foreground(t.Score)
+
+	boxStyle := lipgloss.NewStyle().
+		BorderStyle(lipgloss.DoubleBorder()).
+		BorderForeground(t.Border).
+		Padding(1, 4).
+		Align(lipgloss.Center)
+
+	controlsStyle := lipgloss.NewStyle().
+		Foreground(t.Muted).
+		MarginTop(1)
+
+	title := "🤝 It's a draw! 🤝"
+	if w := v.winner(); w >= 0 {
+		title = fmt.Sprintf("🏆 %s wins! 🏆", v.name(w))
+	}
+
+	p1, p2 := v.players[0], v.players[1]
+	seconds := func(i int) float64 {
+		return float64(v.finished[i]) / float64(p1.cfg.TickRate)
+	}
+	stats := fmt.Sprintf(
+		"%-10s %8s %8s\n%-10s %8d %8d\n%-10s %7.0f%% %7.0f%%\n%-10s %7.1fs %7.1fs",
+		"", "P1", "P2",
+		"Score", p1.score, p2.score,
+		"Accuracy", p1.accuracy(), p2.accuracy(),
+		"Time", seconds(0), seconds(1),
+	)
+
+	content := lipgloss.JoinVertical(
+		lipgloss.Center,
+		titleStyle.Render(title),
+		statsStyle.Render(stats),
+		controlsStyle.Render("r for a rematch, q to quit"),
+	)
+	return lipgloss.Place(
+		p1.width*2+8, p1.height+8,
+		lipgloss.Center, lipgloss.Center,
+		boxStyle.Render(content),
+	)
+}
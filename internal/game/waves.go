@@ -0,0 +1,87 @@
+package game
+
+import "fmt"
+
+// bannerSeconds is how long the between-wave interstitial stays up
+const bannerSeconds = 2
+
+// wave tracks the spawn quota and pacing for one level of play
+type wave struct {
+	number  int
+	quota   int     // balloons to spawn this wave
+	spawned int     // balloons spawned so far
+	speed   float64 // multiplier on balloon ascent rate
+	density float64 // multiplier on spawn chance
+}
+
+// newWave builds wave n; later waves are longer, faster and denser
+func newWave(n int) wave {
+	return wave{
+		number:  n,
+		quota:   8 + 4*(n-1),
+		speed:   1 + 0.15*float64(n-1),
+		density: 1 + 0.2*float64(n-1),
+	}
+}
+
+// exhausted reports whether every balloon in the wave has been spawned
+func (w wave) exhausted() bool {
+	return w.spawned >= w.quota
+}
+
+// bonus is the score awarded for clearing the wave
+func (w wave) bonus() int {
+	return 5 * w.number
+}
+
+// showBanner displays an interstitial message over the board
+func (m *Game) showBanner(text string) {
+	m.banner = text
+	m.bannerTicks = bannerSeconds * m.cfg.TickRate
+}
+
+// advanceWave starts the next wave once the board is empty, awarding the
+// clear bonus if the wave wasn't lost entirely to escapes
+func (m *Game) advanceWave() {
+	if !m.wave.exhausted() || len(m.balloons) > 0 || m.boss != nil {
+		return
+	}
+
+	// A wave counts as cleared only if something in it was shot down
+	// rather than every balloon getting away
+	cleared := m.waveEscaped < m.wave.quota
+	m.emit(event{kind: waveCompleted, wave: m.wave.number, escaped: m.waveEscaped})
+	m.waveEscaped = 0
+
+	// Zen's waves roll on unannounced, with nothing to score
+	if m.currentMode().zen {
+		m.quiver.refill()
+		m.wave = newWave(m.wave.number + 1)
+		return
+	}
+
+	finished, bonus := m.wave.number, 0
+	if cleared {
+		bonus = m.wave.bonus()
+		m.score += bonus
+	}
+	m.quiver.refill()
+	m.wave = newWave(finished + 1)
+	next := fmt.Sprintf("Wave %d", m.wave.number)
+	// A boss has nothing written on it to go by, so modes that write on
+	// balloons go without
+	if m.wave.number%bossEvery == 0 && !m.currentMode().labelled() {
+		next += " — BOSS!"
+		m.spawnBoss()
+	}
+	if cleared {
+		m.showBanner(fmt.Sprintf("Wave %d cleared! +%d  ·  %s", finished, bonus, next))
+		m.announce(fmt.Sprintf("Wave %d cleared +%d", finished, bonus))
+	} else {
+		m.showBanner(next)
+	}
+	m.announce(fmt.Sprintf("Wave %d started", m.wave.number))
+	if m.hasShop() {
+		m.openShop()
+	}
+}
@@ -0,0 +1,48 @@
+package game
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/ashX04/gobowarrow/internal/config"
+)
+
+// endWave leaves g at the end of its first wave with escaped of its
+// balloons having got away
+func endWave(escaped int) Game {
+	cfg := config.Default()
+	cfg.Seed = 1
+	g := New(cfg).Start()
+	g.wave.spawned = g.wave.quota
+	g.balloons = g.balloons[:0]
+	g.waveEscaped = escaped
+	return g
+}
+
+func TestAdvanceWaveAwardsBonusForClearedWave(t *testing.T) {
+	g := endWave(3)
+	g.advanceWave()
+	if g.wave.number != 2 {
+		t.Fatalf("wave = %d, want 2", g.wave.number)
+	}
+	if want := newWave(1).bonus(); g.score != want {
+		t.Errorf("score = %d, want the clear bonus %d", g.score, want)
+	}
+	if !strings.Contains(g.banner, "cleared") {
+		t.Errorf("banner = %q, want it to say the wave was cleared", g.banner)
+	}
+}
+
+func TestAdvanceWaveWithEveryBalloonEscaped(t *testing.T) {
+	g := endWave(newWave(1).quota)
+	g.advanceWave()
+	if g.wave.number != 2 {
+		t.Fatalf("wave = %d, want 2", g.wave.number)
+	}
+	if g.score != 0 {
+		t.Errorf("score = %d, want no bonus for a wave that all got away", g.score)
+	}
+	if strings.Contains(g.banner, "cleared") {
+		t.Errorf("banner = %q, want no mention of clearing", g.banner)
+	}
+}
@@ -0,0 +1,93 @@
+package game
+
+import (
+	"github.com/ashX04/gobowarrow/internal/config"
+	"github.com/ashX04/gobowarrow/internal/render"
+)
+
+// Weather tuning
+const (
+	weatherPeriod  = 45   // seconds from one change of weather to the next
+	weatherSeconds = 20   // how long a shower or fog bank lasts
+	fogLine        = 0.55 // fraction of the board's width past which fog hides balloons
+	rainDrops      = 40   // drops falling at once
+)
+
+// weatherNews is what the event feed says when the weather turns
+var weatherNews = map[string]string{
+	config.WeatherClear: "🌈 The weather clears",
+	config.WeatherRain:  "☔ Rain sets in",
+	config.WeatherFog:   "🌁 Fog rolls in; far balloons are hard to see",
+}
+
+// weatherSetting is the weather the run was set up with: the level's, if
+// it has one, or the player's
+func (m Game) weatherSetting() string {
+	if m.level != nil && m.level.Weather != "" {
+		return m.level.Weather
+	}
+	return m.cfg.Weather
+}
+
+// weatherAt is the weather on the given tick. Changing weather is clear
+// for the first period and then follows a fixed schedule from the tick
+// count rather than the RNG, so seeded runs still play out the same.
+func (m Game) weatherAt(tick int) string {
+	if s := m.weatherSetting(); s != config.WeatherChanging {
+		return s
+	}
+	period := weatherPeriod * m.cfg.TickRate
+	n := tick / period
+	if n == 0 || tick%period >= weatherSeconds*m.cfg.TickRate {
+		return config.WeatherClear
+	}
+	kinds := []string{config.WeatherRain, config.WeatherFog, config.WeatherClear}
+	return kinds[(scatter(n)>>20)%uint32(len(kinds))]
+}
+
+// tickWeather moves the weather on and announces any change in the event
+// feed. A run starting under a clear sky doesn't mention it.
+func (m *Game) tickWeather() {
+	w := m.weatherAt(m.timer)
+	if w == m.weather {
+		return
+	}
+	first := m.weather == ""
+	m.weather = w
+	if !first || w != config.WeatherClear {
+		m.announce(weatherNews[w])
+	}
+}
+
+// fogged reports whether a balloon drawn from column x is lost in the fog
+func (m Game) fogged(x int) bool {
+	return m.weather == config.WeatherFog && x >= m.fogX()
+}
+
+// fogX is the column the fog starts at, a way across the whole world
+func (m Game) fogX() int {
+	return int(fogLine * float64(m.width))
+}
+
+// drawWeather draws rain or the edge of the fog into the backdrop, across
+// the whole world. Rain falls a row a tick, or hangs still with reduced
+// motion.
+func (m Game) drawWeather(f *render.FrameBuffer) {
+	style := m.sceneryStyle()
+	switch m.weather {
+	case config.WeatherRain:
+		fall := m.timer
+		if m.cfg.ReducedMotion {
+			fall = 0
+		}
+		for i := range rainDrops * m.width / f.Width() {
+			h := scatter(i)
+			f.Backdrop((int(h>>8)+fall)%f.Height(), int(h>>16)%m.width, "'", style)
+		}
+	case config.WeatherFog:
+		x := m.fogX()
+		for y := 1; y < f.Height(); y += 3 {
+			f.Backdrop(y, x, repeatFrom("~   ", y, m.width-x), style)
+		}
+	}
+}
@@ -0,0 +1,133 @@
+// Package leaderboard talks to an optional online leaderboard over HTTPS,
+// or keeps a local one for a game served to many players.
+//
+// The server accepts a JSON Submission as a POST to <url>/scores and lists
+// the best entries for a mode with GET <url>/scores?mode=<id>&limit=<n>.
+package leaderboard
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ashX04/gobowarrow/internal/replay"
+)
+
+// TopSize is how many entries the leaderboard screen lists
+const TopSize = 50
+
+// timeout bounds every request so an unreachable server can't hang a command
+const timeout = 5 * time.Second
+
+// Submission is a finished run sent to the server
+type Submission struct {
+	Name       string `json:"name"`
+	Mode       string `json:"mode"`
+	Score      int    `json:"score"`
+	Seed       int64  `json:"seed"`
+	ReplayHash string `json:"replay_hash"` // lets the server match a run to its replay
+}
+
+// Entry is one row of the online table
+type Entry struct {
+	Rank  int    `json:"rank"`
+	Name  string `json:"name"`
+	Score int    `json:"score"`
+	Seed  int64  `json:"seed"`
+}
+
+// Board is somewhere finished runs are ranked: an online server, or the
+// shared table of a game served over SSH
+type Board interface {
+	Submit(ctx context.Context, s Submission) error
+	Top(ctx context.Context, mode string, n int) ([]Entry, error)
+}
+
+// Client is a leaderboard server connection
+type Client struct {
+	base string
+	http *http.Client
+}
+
+// New returns a client for the server at base
+func New(base string) *Client {
+	return &Client{
+		base: strings.TrimRight(base, "/"),
+		http: &http.Client{Timeout: timeout},
+	}
+}
+
+// WithHTTPClient returns a copy of c that sends requests through hc
+func (c *Client) WithHTTPClient(hc *http.Client) *Client {
+	out := *c
+	out.http = hc
+	return &out
+}
+
+// Submit posts a finished run
+func (c *Client) Submit(ctx context.Context, s Submission) error {
+	body, err := json.Marshal(s)
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.base+"/scores", bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode/100 != 2 {
+		return fmt.Errorf("submit: server returned %s", resp.Status)
+	}
+	return nil
+}
+
+// Top fetches the best n entries for mode
+func (c *Client) Top(ctx context.Context, mode string, n int) ([]Entry, error) {
+	query := url.Values{"mode": {mode}, "limit": {strconv.Itoa(n)}}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.base+"/scores?"+query.Encode(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode/100 != 2 {
+		return nil, fmt.Errorf("top: server returned %s", resp.Status)
+	}
+
+	var entries []Entry
+	if err := json.NewDecoder(resp.Body).Decode(&entries); err != nil {
+		return nil, fmt.Errorf("top: %w", err)
+	}
+	if len(entries) > n {
+		entries = entries[:n]
+	}
+	return entries, nil
+}
+
+// ReplayHash fingerprints a recorded run
+func ReplayHash(r replay.Run) string {
+	data, err := json.Marshal(r)
+	if err != nil {
+		return ""
+	}
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,122 @@
+package leaderboard
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+)
+
+// Local is a leaderboard kept by this process for everyone playing on a
+// server. Players are told apart by a key rather than their name, and only
+// their best run in each mode is ranked.
+type Local struct {
+	mu   sync.Mutex // guards best and the file, as every session submits here
+	path string
+	best map[string]map[string]Entry // mode -> player key -> best entry
+}
+
+// LocalPath returns the server's table location under the user config dir
+func LocalPath() (string, error) {
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "bowarrow", "server_scores.json"), nil
+}
+
+// LoadLocal reads a table from path, which it also saves back to. A missing
+// file yields an empty table.
+func LoadLocal(path string) (*Local, error) {
+	l := &Local{path: path, best: map[string]map[string]Entry{}}
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return l, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(data, &l.best); err != nil {
+		return nil, err
+	}
+	return l, nil
+}
+
+// For returns the board one player sees. Their runs are filed under key,
+// and the name they submit with replaces any earlier one.
+func (l *Local) For(key string) Board {
+	return player{local: l, key: key}
+}
+
+// submit records s for the player with key, saving if it's their best
+func (l *Local) submit(key string, s Submission) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	players := l.best[s.Mode]
+	if players == nil {
+		players = map[string]Entry{}
+		l.best[s.Mode] = players
+	}
+	if prev, ok := players[key]; ok && prev.Score >= s.Score {
+		return nil
+	}
+	players[key] = Entry{Name: s.Name, Score: s.Score, Seed: s.Seed}
+	return l.save()
+}
+
+// save writes the table to disk, creating the parent directory if needed
+func (l *Local) save() error {
+	if l.path == "" {
+		return nil
+	}
+	if err := os.MkdirAll(filepath.Dir(l.path), 0o755); err != nil {
+		return err
+	}
+	data, err := json.MarshalIndent(l.best, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(l.path, data, 0o644)
+}
+
+// Top returns the best n entries for mode, ranked
+func (l *Local) Top(_ context.Context, mode string, n int) ([]Entry, error) {
+	l.mu.Lock()
+	entries := make([]Entry, 0, len(l.best[mode]))
+	for _, e := range l.best[mode] {
+		entries = append(entries, e)
+	}
+	l.mu.Unlock()
+
+	sort.SliceStable(entries, func(i, j int) bool {
+		if entries[i].Score != entries[j].Score {
+			return entries[i].Score > entries[j].Score
+		}
+		return entries[i].Name < entries[j].Name
+	})
+	if len(entries) > n {
+		entries = entries[:n]
+	}
+	for i := range entries {
+		entries[i].Rank = i + 1
+	}
+	return entries, nil
+}
+
+// player is one player's view of a Local table
+type player struct {
+	local *Local
+	key   string
+}
+
+func (p player) Submit(_ context.Context, s Submission) error {
+	return p.local.submit(p.key, s)
+}
+
+func (p player) Top(ctx context.Context, mode string, n int) ([]Entry, error) {
+	return p.local.Top(ctx, mode, n)
+}
@@ -0,0 +1,211 @@
+// Package level loads authored level definitions from JSON files.
+package level
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/rivo/uniseg"
+)
+
+// Spawn patterns
+const (
+	PatternRandom = "random" // roll Chance every tick
+	PatternStream = "stream" // one balloon every Interval seconds
+	PatternBurst  = "burst"  // BurstSize balloons every Interval seconds
+)
+
+// Obstacle kinds
+const (
+	ObstacleWall   = "wall"   // stops arrows dead
+	ObstacleMirror = "mirror" // bounces arrows that land on it from above or below
+)
+
+// Limits on custom balloon art so it fits on the board
+const (
+	MaxArtWidth  = 16
+	MaxArtHeight = 8
+)
+
+// Level describes one authored stage
+type Level struct {
+	Name       string        `json:"name"`
+	Balloons   []BalloonType `json:"balloons"`
+	Spawn      Spawn         `json:"spawn"`
+	Wind       float64       `json:"wind"`        // sideways drift in cells per second, negative blows left
+	Weather    string        `json:"weather"`     // clear, changing, rain or fog; empty leaves it to the player's config
+	WorldWidth int           `json:"world_width"` // columns the level spans, panned across when wider than the board; 0 is the board's width
+	Win        Win           `json:"win"`
+
+	// Script names a Lua file, relative to the level file, that adds its
+	// own spawns, events and win conditions. Source is what it holds.
+	Script string `json:"script"`
+	Source string `json:"-"`
+
+	// Obstacles are laid over the board in order, so a later one covers an
+	// earlier one where they overlap
+	Obstacles []Obstacle `json:"obstacles"`
+}
+
+// Obstacle is a rectangle of wall or mirror tiles on the board. Balloons
+// float past obstacles; only arrows run into them.
+type Obstacle struct {
+	Kind   string `json:"kind"`
+	X      int    `json:"x"` // column of the top-left tile
+	Y      int    `json:"y"` // row of the top-left tile
+	Width  int    `json:"width"`
+	Height int    `json:"height"`
+}
+
+// BalloonType is one kind of balloon the level can spawn
+type BalloonType struct {
+	Sprite string   `json:"sprite"` // name of a built-in sprite
+	Art    []string `json:"art"`    // custom art, used instead of Sprite
+	Color  string   `json:"color"`  // 256-color code
+	Weight int      `json:"weight"` // relative spawn frequency
+}
+
+// Spawn controls when balloons appear
+type Spawn struct {
+	Pattern   string  `json:"pattern"`
+	Chance    float64 `json:"chance"`     // per-tick chance for the random pattern
+	Interval  float64 `json:"interval"`   // seconds between stream/burst spawns
+	BurstSize int     `json:"burst_size"` // balloons per burst
+	Quota     int     `json:"quota"`      // total balloons; 0 is endless
+}
+
+// Win holds the level's win and lose conditions
+type Win struct {
+	Score      int `json:"score"`       // score needed to win; 0 leaves it to the script
+	TimeLimit  int `json:"time_limit"`  // seconds before the level is lost; 0 disables
+	MaxEscaped int `json:"max_escaped"` // escapes before the level is lost; 0 disables
+}
+
+// Load reads and validates a level file, filling in defaults, along with
+// its script if it has one
+func Load(path string) (*Level, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var l Level
+	if err := json.Unmarshal(data, &l); err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	l.applyDefaults()
+	if err := l.Validate(); err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	if l.Script != "" {
+		src, err := os.ReadFile(filepath.Join(filepath.Dir(path), l.Script))
+		if err != nil {
+			return nil, fmt.Errorf("%s: script: %w", path, err)
+		}
+		l.Source = string(src)
+	}
+	return &l, nil
+}
+
+func (l *Level) applyDefaults() {
+	if l.Name == "" {
+		l.Name = "Custom level"
+	}
+	if l.Spawn.Pattern == "" {
+		l.Spawn.Pattern = PatternRandom
+	}
+	if l.Spawn.Pattern == PatternRandom && l.Spawn.Chance == 0 {
+		l.Spawn.Chance = 0.1
+	}
+	if l.Spawn.Interval == 0 {
+		l.Spawn.Interval = 1
+	}
+	if l.Spawn.BurstSize == 0 {
+		l.Spawn.BurstSize = 3
+	}
+	for i := range l.Balloons {
+		if l.Balloons[i].Weight == 0 {
+			l.Balloons[i].Weight = 1
+		}
+	}
+	for i := range l.Obstacles {
+		o := &l.Obstacles[i]
+		if o.Width == 0 {
+			o.Width = 1
+		}
+		if o.Height == 0 {
+			o.Height = 1
+		}
+	}
+}
+
+// Validate rejects levels the game can't run
+func (l *Level) Validate() error {
+	if len(l.Balloons) == 0 {
+		return errors.New("level needs at least one balloon type")
+	}
+	for i, b := range l.Balloons {
+		if b.Sprite == "" && len(b.Art) == 0 {
+			return fmt.Errorf("balloon %d needs a sprite or art", i)
+		}
+		if len(b.Art) > MaxArtHeight {
+			return fmt.Errorf("balloon %d art is taller than %d rows", i, MaxArtHeight)
+		}
+		for _, line := range b.Art {
+			if uniseg.StringWidth(line) > MaxArtWidth {
+				return fmt.Errorf("balloon %d art is wider than %d columns", i, MaxArtWidth)
+			}
+		}
+		if b.Weight < 0 {
+			return fmt.Errorf("balloon %d weight must not be negative", i)
+		}
+	}
+
+	switch l.Spawn.Pattern {
+	case PatternRandom, PatternStream, PatternBurst:
+	default:
+		return fmt.Errorf("unknown spawn pattern %q", l.Spawn.Pattern)
+	}
+	if l.Spawn.Chance < 0 || l.Spawn.Chance > 1 {
+		return fmt.Errorf("spawn chance must be between 0 and 1, got %g", l.Spawn.Chance)
+	}
+	if l.Spawn.Interval < 0 || l.Spawn.BurstSize < 0 || l.Spawn.Quota < 0 {
+		return errors.New("spawn interval, burst_size and quota must not be negative")
+	}
+
+	for i, o := range l.Obstacles {
+		switch o.Kind {
+		case ObstacleWall, ObstacleMirror:
+		default:
+			return fmt.Errorf("obstacle %d: unknown kind %q", i, o.Kind)
+		}
+		if o.X < 0 || o.Y < 0 || o.Width < 0 || o.Height < 0 {
+			return fmt.Errorf("obstacle %d: x, y, width and height must not be negative", i)
+		}
+	}
+
+	if l.WorldWidth < 0 {
+		return errors.New("world_width must not be negative")
+	}
+
+	// A scripted level may decide for itself when it's won
+	if l.Win.Score < 0 || l.Win.Score == 0 && l.Script == "" {
+		return errors.New("win score must be positive")
+	}
+	if l.Win.TimeLimit < 0 || l.Win.MaxEscaped < 0 {
+		return errors.New("time_limit and max_escaped must not be negative")
+	}
+	return nil
+}
+
+// TotalWeight sums the spawn weights of all balloon types
+func (l *Level) TotalWeight() int {
+	total := 0
+	for _, b := range l.Balloons {
+		total += b.Weight
+	}
+	return total
+}
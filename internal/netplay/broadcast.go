@@ -0,0 +1,137 @@
+package netplay
+
+import (
+	"net"
+	"sync"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// Broadcaster streams frames to any number of read-only watchers. Keys a
+// watcher sends are ignored.
+type Broadcaster struct {
+	l net.Listener
+
+	mu       sync.Mutex // guards watchers and last
+	watchers map[*Conn]bool
+	last     string // latest frame, sent to watchers as they arrive
+}
+
+// Broadcast accepts watchers on addr until closed
+func Broadcast(addr string) (*Broadcaster, error) {
+	l, err := Listen(addr)
+	if err != nil {
+		return nil, err
+	}
+	b := &Broadcaster{l: l, watchers: map[*Conn]bool{}}
+	go b.accept()
+	return b, nil
+}
+
+func (b *Broadcaster) accept() {
+	for {
+		c, err := Accept(b.l)
+		if err != nil {
+			return
+		}
+		b.mu.Lock()
+		b.watchers[c] = true
+		if b.last != "" {
+			c.Send(Message{Type: FrameMsg, Frame: b.last})
+		}
+		b.mu.Unlock()
+		go b.watch(c)
+	}
+}
+
+// watch drops c once the watcher leaves
+func (b *Broadcaster) watch(c *Conn) {
+	for {
+		msg, err := c.Receive()
+		if err != nil || msg.Type == ByeMsg {
+			break
+		}
+	}
+	b.mu.Lock()
+	delete(b.watchers, c)
+	b.mu.Unlock()
+	c.Close()
+}
+
+// watched reports whether anyone is watching
+func (b *Broadcaster) watched() bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return len(b.watchers) > 0
+}
+
+// Send shows frame to every watcher, skipping repeats of the last one
+func (b *Broadcaster) Send(frame string) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if frame == b.last {
+		return
+	}
+	b.last = frame
+	for c := range b.watchers {
+		c.Send(Message{Type: FrameMsg, Frame: frame})
+	}
+}
+
+// Close stops accepting watchers and says goodbye to those watching
+func (b *Broadcaster) Close() {
+	b.l.Close()
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	for c := range b.watchers {
+		c.Close()
+	}
+	b.watchers = map[*Conn]bool{}
+}
+
+// castInterval is how often a Streamed model renders a frame for its
+// watchers
+const castInterval = time.Second / 30
+
+// castMsg asks a Streamed model to send watchers its latest frame
+type castMsg struct{}
+
+// Streamed is a model whose frames are mirrored to watchers. It renders
+// for them on its own clock rather than on every message, so keys, chat
+// and saves don't draw the game twice, and only while someone is
+// watching.
+type Streamed struct {
+	model tea.Model
+	cast  *Broadcaster
+}
+
+// Stream mirrors model's frames through cast
+func Stream(model tea.Model, cast *Broadcaster) Streamed {
+	return Streamed{model: model, cast: cast}
+}
+
+// next schedules the next frame for the watchers
+func (s Streamed) next() tea.Cmd {
+	return tea.Tick(castInterval, func(time.Time) tea.Msg { return castMsg{} })
+}
+
+func (s Streamed) Init() tea.Cmd {
+	return tea.Batch(s.model.Init(), s.next())
+}
+
+func (s Streamed) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	if _, ok := msg.(castMsg); ok {
+		if s.cast.watched() {
+			s.cast.Send(s.model.View())
+		}
+		return s, s.next()
+	}
+	var cmd tea.Cmd
+	s.model, cmd = s.model.Update(msg)
+	return s, cmd
+}
+
+func (s Streamed) View() string {
+	return s.model.View()
+}
@@ -0,0 +1,135 @@
+// Package netplay carries networked play. The host runs the only
+// simulation: remote players send their key presses and get rendered frames
+// back, while watchers only get the frames. Messages are JSON objects, one
+// per line.
+package netplay
+
+import (
+	"bufio"
+	"encoding/json"
+	"net"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Message types
+const (
+	KeyMsg   = "key"   // a key press from a remote player
+	FrameMsg = "frame" // a rendered frame from the host
+	ByeMsg   = "bye"   // the sender is leaving
+)
+
+// sendQueue is how many messages may wait for a slow peer before new
+// ones are dropped
+const sendQueue = 64
+
+// dialTimeout bounds how long joining a host may take
+const dialTimeout = 5 * time.Second
+
+// Message is one line of the protocol
+type Message struct {
+	Type  string `json:"type"`
+	Key   string `json:"key,omitempty"`
+	Frame string `json:"frame,omitempty"`
+}
+
+// Conn is one end of a match connection. Sends are queued and written in
+// the background so a slow peer never stalls the game loop.
+type Conn struct {
+	conn net.Conn
+	dec  *json.Decoder
+
+	mu     sync.Mutex // guards out against sends after Close
+	out    chan Message
+	closed bool
+}
+
+// NewConn wraps an established connection
+func NewConn(conn net.Conn) *Conn {
+	c := &Conn{
+		conn: conn,
+		dec:  json.NewDecoder(bufio.NewReader(conn)),
+		out:  make(chan Message, sendQueue),
+	}
+	go c.write()
+	return c
+}
+
+// network picks the socket type for addr: paths are unix sockets, anything
+// else is a TCP address
+func network(addr string) string {
+	if strings.ContainsRune(addr, '/') {
+		return "unix"
+	}
+	return "tcp"
+}
+
+// Listen opens addr for peers to connect to
+func Listen(addr string) (net.Listener, error) {
+	return net.Listen(network(addr), addr)
+}
+
+// Dial joins the host at addr
+func Dial(addr string) (*Conn, error) {
+	conn, err := net.DialTimeout(network(addr), addr, dialTimeout)
+	if err != nil {
+		return nil, err
+	}
+	return NewConn(conn), nil
+}
+
+// Accept waits for the next peer on l
+func Accept(l net.Listener) (*Conn, error) {
+	conn, err := l.Accept()
+	if err != nil {
+		return nil, err
+	}
+	return NewConn(conn), nil
+}
+
+func (c *Conn) write() {
+	enc := json.NewEncoder(c.conn)
+	for msg := range c.out {
+		if err := enc.Encode(msg); err != nil {
+			c.conn.Close()
+			return
+		}
+	}
+}
+
+// Send queues msg for the peer. If the peer has fallen too far behind the
+// message is dropped; the next frame supersedes it anyway.
+func (c *Conn) Send(msg Message) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.closed {
+		return
+	}
+	select {
+	case c.out <- msg:
+	default:
+	}
+}
+
+// Receive blocks until the peer's next message arrives
+func (c *Conn) Receive() (Message, error) {
+	var msg Message
+	err := c.dec.Decode(&msg)
+	return msg, err
+}
+
+// Close says goodbye and hangs up once queued messages are written
+func (c *Conn) Close() {
+	c.Send(Message{Type: ByeMsg})
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.closed {
+		return
+	}
+	c.closed = true
+	close(c.out)
+	// Give the writer a moment to flush before the socket goes away
+	time.AfterFunc(time.Second, func() { c.conn.Close() })
+}
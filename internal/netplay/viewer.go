@@ -0,0 +1,70 @@
+package netplay
+
+import (
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// receivedMsg carries one message from the host
+type receivedMsg struct {
+	msg Message
+	err error
+}
+
+// Viewer is a Bubble Tea model that shows the frames a host streams. With
+// input enabled it also forwards the player's key presses to the host.
+type Viewer struct {
+	conn   *Conn
+	input  bool
+	frame  string
+	status string // shown until the first frame arrives, and after leaving
+}
+
+// NewViewer shows frames from conn, forwarding keys when input is true
+func NewViewer(conn *Conn, input bool) Viewer {
+	return Viewer{conn: conn, input: input, status: "Waiting for the host…"}
+}
+
+func (v Viewer) Init() tea.Cmd {
+	return v.receive()
+}
+
+// receive waits for the host's next message
+func (v Viewer) receive() tea.Cmd {
+	return func() tea.Msg {
+		msg, err := v.conn.Receive()
+		return receivedMsg{msg: msg, err: err}
+	}
+}
+
+func (v Viewer) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		switch msg.String() {
+		case "q", "ctrl+c":
+			v.conn.Close()
+			return v, tea.Quit
+		}
+		if v.input {
+			v.conn.Send(Message{Type: KeyMsg, Key: msg.String()})
+		}
+
+	case receivedMsg:
+		if msg.err != nil || msg.msg.Type == ByeMsg {
+			v.frame = ""
+			v.status = "The host has left."
+			return v, tea.Quit
+		}
+		if msg.msg.Type == FrameMsg {
+			v.frame = msg.msg.Frame
+		}
+		return v, v.receive()
+	}
+	return v, nil
+}
+
+func (v Viewer) View() string {
+	if v.frame == "" {
+		return v.status + "\n"
+	}
+	return v.frame
+}
@@ -0,0 +1,53 @@
+// Package physics provides the small ballistic model used for projectiles.
+package physics
+
+import "math"
+
+// DefaultGravity is the downward acceleration in rows per second squared.
+// It is small enough that a level tapped shot drops a handful of rows
+// across a standard board, while fast charged shots fly nearly flat.
+const DefaultGravity = 1.0
+
+// Vec is a 2D vector in board cells, with Y growing downward
+type Vec struct {
+	X, Y float64
+}
+
+// Add returns v + o
+func (v Vec) Add(o Vec) Vec {
+	return Vec{v.X + o.X, v.Y + o.Y}
+}
+
+// Scale returns v multiplied by k
+func (v Vec) Scale(k float64) Vec {
+	return Vec{v.X * k, v.Y * k}
+}
+
+// Cell rounds the vector to the nearest board cell
+func (v Vec) Cell() (int, int) {
+	return int(math.Round(v.X)), int(math.Round(v.Y))
+}
+
+// Body is a point mass with a position and velocity
+type Body struct {
+	Pos Vec
+	Vel Vec
+}
+
+// Step advances the body by dt seconds under gravity using semi-implicit
+// Euler integration, which stays stable at the coarse tick rates we run
+func (b *Body) Step(gravity, dt float64) {
+	b.Vel.Y += gravity * dt
+	b.Pos = b.Pos.Add(b.Vel.Scale(dt))
+}
+
+// Launch returns a body at pos moving at speed, in cells per second, in the
+// direction of (1, slope), so slope is rows climbed or dropped per column
+// travelled
+func Launch(pos Vec, speed, slope float64) Body {
+	norm := math.Hypot(1, slope)
+	return Body{
+		Pos: pos,
+		Vel: Vec{X: speed / norm, Y: speed * slope / norm},
+	}
+}
@@ -0,0 +1,4 @@
+// Package plugins loads the Go plugins that add entities to the game.
+// Builds without the plugins tag can't load any, which keeps the default
+// build free of cgo.
+package plugins
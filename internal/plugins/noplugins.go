@@ -0,0 +1,18 @@
+//go:build !plugins
+
+package plugins
+
+import (
+	"errors"
+
+	"github.com/ashX04/gobowarrow/extension"
+)
+
+// errNoPlugins is returned when this build left plugin loading out, since
+// it needs cgo
+var errNoPlugins = errors.New("built without plugin support; rebuild with -tags plugins")
+
+// Open would load the plugin at path
+func Open(path string) ([]extension.Kind, error) {
+	return nil, errNoPlugins
+}
@@ -0,0 +1,27 @@
+//go:build plugins
+
+package plugins
+
+import (
+	"fmt"
+	"plugin"
+
+	"github.com/ashX04/gobowarrow/extension"
+)
+
+// Open loads the plugin at path and returns the kinds of entity it adds
+func Open(path string) ([]extension.Kind, error) {
+	p, err := plugin.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	sym, err := p.Lookup(extension.Symbol)
+	if err != nil {
+		return nil, err
+	}
+	kinds, ok := sym.(extension.Kinds)
+	if !ok {
+		return nil, fmt.Errorf("%s: %s is a %T, not a func() []extension.Kind", path, extension.Symbol, sym)
+	}
+	return kinds(), nil
+}
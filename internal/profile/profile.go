@@ -0,0 +1,151 @@
+// Package profile keeps the player's progress across runs: lifetime XP,
+// the level it adds up to and what each level unlocks.
+package profile
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+)
+
+// levelStep is the XP the first level up takes. Each level after takes
+// this much more than the one before.
+const levelStep = 250
+
+// Profile is the player's lifetime progress
+type Profile struct {
+	XP   int `json:"xp"`
+	Runs int `json:"runs"` // finished runs that earned XP
+}
+
+// DefaultPath returns the profile's location under the user config dir
+func DefaultPath() (string, error) {
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "bowarrow", "profile.json"), nil
+}
+
+// Load reads the profile from disk. A missing file starts a new one.
+func Load(path string) (Profile, error) {
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return Profile{}, nil
+	}
+	if err != nil {
+		return Profile{}, err
+	}
+
+	var p Profile
+	if err := json.Unmarshal(data, &p); err != nil {
+		return Profile{}, err
+	}
+	return p, nil
+}
+
+// Save writes the profile to disk, creating the parent directory if needed
+func (p Profile) Save(path string) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+	data, err := json.MarshalIndent(p, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, 0o644)
+}
+
+// Earn returns the profile with a finished run's XP added
+func (p Profile) Earn(xp int) Profile {
+	p.XP += max(xp, 0)
+	p.Runs++
+	return p
+}
+
+// LevelXP is the lifetime XP it takes to reach level. Everyone starts at
+// level 1.
+func LevelXP(level int) int {
+	return levelStep * (level - 1) * level / 2
+}
+
+// Level is the level the profile's XP has reached
+func (p Profile) Level() int {
+	level := 1
+	for LevelXP(level+1) <= p.XP {
+		level++
+	}
+	return level
+}
+
+// Kind is what sort of thing an unlock is
+type Kind int
+
+const (
+	BowSkin     Kind = iota // recolors the archer and its arrows
+	BalloonPack             // recolors the balloons
+	Mode                    // a way to play from the menu
+)
+
+// Unlock is something a level opens up. Its ID is the name the config
+// or the mode list knows it by.
+type Unlock struct {
+	ID    string
+	Kind  Kind
+	Name  string
+	Level int
+}
+
+// Unlocks lists everything levels open up, in level order
+var Unlocks = []Unlock{
+	{ID: "survival", Kind: Mode, Name: "Survival mode", Level: 2},
+	{ID: "yew", Kind: BowSkin, Name: "Yew bow", Level: 2},
+	{ID: "sunset", Kind: BalloonPack, Name: "Sunset balloons", Level: 3},
+	{ID: "hardcore", Kind: Mode, Name: "Hardcore mode", Level: 4},
+	{ID: "gilded", Kind: BowSkin, Name: "Gilded bow", Level: 5},
+	{ID: "ocean", Kind: BalloonPack, Name: "Ocean balloons", Level: 6},
+	{ID: "frost", Kind: BowSkin, Name: "Frost bow", Level: 7},
+	{ID: "candy", Kind: BalloonPack, Name: "Candy balloons", Level: 8},
+	{ID: "ember", Kind: BowSkin, Name: "Ember bow", Level: 10},
+}
+
+// Lookup returns the unlock of kind with id
+func Lookup(kind Kind, id string) (Unlock, bool) {
+	for _, u := range Unlocks {
+		if u.Kind == kind && u.ID == id {
+			return u, true
+		}
+	}
+	return Unlock{}, false
+}
+
+// Names lists the IDs of every unlock of kind
+func Names(kind Kind) []string {
+	var names []string
+	for _, u := range Unlocks {
+		if u.Kind == kind {
+			names = append(names, u.ID)
+		}
+	}
+	return names
+}
+
+// Unlocked reports whether the profile has reached the level that opens
+// the unlock of kind with id. Anything no level gates is always open.
+func (p Profile) Unlocked(kind Kind, id string) bool {
+	u, ok := Lookup(kind, id)
+	return !ok || p.Level() >= u.Level
+}
+
+// Opened lists what going from before to p unlocked, in level order
+func (p Profile) Opened(before Profile) []Unlock {
+	var opened []Unlock
+	from, to := before.Level(), p.Level()
+	for _, u := range Unlocks {
+		if u.Level > from && u.Level <= to {
+			opened = append(opened, u)
+		}
+	}
+	return opened
+}
@@ -0,0 +1,12 @@
+package render
+
+import "regexp"
+
+// ansiEscape matches the CSI and OSC sequences lipgloss emits
+var ansiEscape = regexp.MustCompile(`\x1b\[[0-9;:?]*[ -/]*[@-~]|\x1b\][^\x07\x1b]*(?:\x07|\x1b\\)`)
+
+// StripANSI removes styling escapes from s, leaving just the text. Frames
+// compared as plain text don't depend on the terminal's color support.
+func StripANSI(s string) string {
+	return ansiEscape.ReplaceAllString(s, "")
+}
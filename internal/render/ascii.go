@@ -0,0 +1,76 @@
+package render
+
+import (
+	"strings"
+	"unicode/utf8"
+)
+
+// asciiGlyphs are the plain stand-ins for the game's non-ASCII glyphs.
+// Each is padded to its glyph's width so layouts don't shift.
+var asciiGlyphs = map[rune]string{
+	// Borders
+	'╭': "+", '╮': "+", '╰': "+", '╯': "+", '┌': "+", '┐': "+", '└': "+", '┘': "+",
+	'╔': "+", '╗': "+", '╚': "+", '╝': "+", '─': "-", '━': "-", '│': "|", '┃': "|",
+	'═': "=", '║': "|",
+
+	// Balloons, the boss and explosions
+	'´': "'", '‾': "-", '○': "o", '•': "*", '★': "*", '✦': "+", '‿': "_",
+	'◣': "\\", '◢': "/", '●': "@", '·': ".", '∘': "o",
+	'▲': "A", '■': "#", '»': ">", '≡': "=", '◷': "@", '×': "x", '❄': "*",
+
+	// Arrows and the quiver
+	'▷': ")", '≻': "}", '➤': ">", '⮜': "<", '→': ">", '←': "<", '↑': "^", '↓': "v",
+	'⇥': "]", '⋔': "Y", '✹': "*", '➶': "/", '∞': "~",
+
+	// HUD and menus
+	'♥': "#", '♡': ".", '█': "#", '░': ".", '▓': "%", '◀': "<", '▶': ">", '▸': ">",
+	'—': "-", '–': "-", '…': ".", '°': "'", '✗': "x",
+	'🎯': "()", '🎈': "()", '💥': "**", '🏆': "##", '👑': "^^", '📣': ">>",
+	'🤝': "==", '⚡': "!!", '⏱': "T", '🏹': "|)", '🎁': "[]", '🍏': "()", '➳': ">",
+	'▬': "=", '☔': "''", '🌁': "~~", '🌈': "()",
+	'🔓': "[]",
+}
+
+// ASCII swaps every non-ASCII glyph in s for a plain one of the same width,
+// for terminals whose fonts lack them. A glyph is a whole grapheme cluster,
+// so an emoji and its modifiers become one stand-in. Glyphs with no
+// stand-in become "?"; zero-width ones are dropped. Escape sequences are
+// ASCII, so styling survives.
+func ASCII(s string) string {
+	var b strings.Builder
+	b.Grow(len(s))
+	for _, g := range Glyphs(s) {
+		if isASCII(g.Text) {
+			b.WriteString(g.Text)
+			continue
+		}
+		r, _ := utf8.DecodeRuneInString(g.Text)
+		plain, ok := asciiGlyphs[r]
+		switch {
+		case g.Width == 0:
+			continue
+		case r == brailleBase:
+			plain = " "
+		case r > brailleBase && r <= brailleBase+0xFF:
+			// Any dots at all stand out as a star
+			plain = "*"
+		case !ok:
+			plain = "?"
+		}
+		b.WriteString(plain)
+		if pad := g.Width - len(plain); pad > 0 {
+			b.WriteString(strings.Repeat(plain[len(plain)-1:], pad))
+		}
+	}
+	return b.String()
+}
+
+// isASCII reports whether s is plain ASCII
+func isASCII(s string) bool {
+	for i := range len(s) {
+		if s[i] >= utf8.RuneSelf {
+			return false
+		}
+	}
+	return true
+}
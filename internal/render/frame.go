@@ -0,0 +1,227 @@
+// Package render draws the board: a cell frame buffer that only rebuilds
+// rows which changed, and the renderers that plot arrows and trails into it.
+package render
+
+import (
+	"slices"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// Style is a comparable description of a cell's look, so unchanged
+// cells can be recognised between frames without re-rendering them. The
+// colors must be comparable values such as lipgloss.Color or
+// lipgloss.AdaptiveColor.
+type Style struct {
+	FG, BG lipgloss.TerminalColor
+	Bold   bool
+	Faint  bool
+}
+
+// style builds the lipgloss style the cell is rendered with
+func (s Style) style() lipgloss.Style {
+	style := lipgloss.NewStyle().Bold(s.Bold).Faint(s.Faint)
+	if s.FG != nil {
+		style = style.Foreground(s.FG)
+	}
+	if s.BG != nil {
+		style = style.Background(s.BG)
+	}
+	return style
+}
+
+// cell is one board position. A glyph wider than one column covers the
+// cells after it, which are marked as covered and skipped when rendering.
+type cell struct {
+	glyph   string
+	style   Style
+	raw     bool // glyph is already styled and is written as-is
+	covered bool // hidden under the wide glyph to its left
+	back    bool // part of the backdrop, behind everything else
+}
+
+var blankCell = cell{glyph: " "}
+
+// FrameBuffer is the board the View draws into. It keeps the previous
+// frame so only rows that changed are rebuilt, and caches styled glyphs so
+// each distinct cell is only rendered through lipgloss once.
+type FrameBuffer struct {
+	width, height int
+	mirrored      bool // drawn flipped left to right
+	scroll        int  // columns everything is shifted left by
+	cells         []cell
+	prev          []cell
+	rows          []string
+	styled        map[cell]string
+}
+
+// NewFrameBuffer returns an empty board of the given size
+func NewFrameBuffer(width, height int) *FrameBuffer {
+	f := &FrameBuffer{
+		width:  width,
+		height: height,
+		cells:  make([]cell, width*height),
+		prev:   make([]cell, width*height),
+		rows:   make([]string, height),
+		styled: make(map[cell]string),
+	}
+	// Force every row to render on the first frame
+	for i := range f.prev {
+		f.prev[i].covered = true
+	}
+	return f
+}
+
+// Width is the board width in cells
+func (f *FrameBuffer) Width() int { return f.width }
+
+// Height is the board height in rows
+func (f *FrameBuffer) Height() int { return f.height }
+
+// Mirror flips everything drawn from now on left to right, so a board
+// whose archer stands on the left is shown with it on the right. Glyphs
+// that point a way are turned round; text still reads left to right.
+func (f *FrameBuffer) Mirror() {
+	f.mirrored = true
+}
+
+// Scroll shifts everything drawn from now on x columns to the left, so a
+// world wider than the board can be shown a piece at a time. Positions
+// are given in the world's columns until it's scrolled back to 0.
+func (f *FrameBuffer) Scroll(x int) {
+	f.scroll = x
+}
+
+// Clear blanks the board for a new frame
+func (f *FrameBuffer) Clear() {
+	for i := range f.cells {
+		f.cells[i] = blankCell
+	}
+}
+
+// InBounds reports whether (x, y) is a cell on the board, ignoring any
+// scroll
+func (f *FrameBuffer) InBounds(x, y int) bool {
+	return x >= 0 && x < f.width && y >= 0 && y < f.height
+}
+
+// Blank reports whether (x, y) is on the board and nothing but the
+// backdrop is drawn there
+func (f *FrameBuffer) Blank(x, y int) bool {
+	x = f.column(x, 1)
+	if !f.InBounds(x, y) {
+		return false
+	}
+	c := f.cells[y*f.width+x]
+	return c == blankCell || c.back
+}
+
+// Set draws glyph at (x, y), clipping anything off the board
+func (f *FrameBuffer) Set(x, y int, glyph string, style Style) {
+	width := Width(glyph)
+	if f.mirrored {
+		glyph = mirrorGlyph(glyph)
+	}
+	f.put(f.column(x, width), y, cell{glyph: glyph, style: style}, width)
+}
+
+// SetRaw places an already styled string of the given width at (x, y)
+func (f *FrameBuffer) SetRaw(x, y int, s string, width int) {
+	f.put(f.column(x, width), y, cell{glyph: s, raw: true}, width)
+}
+
+// Text draws a run of glyphs starting at (col, row). Each grapheme cluster
+// is one cell however many runes it takes, and wide ones cover the cells
+// after them.
+func (f *FrameBuffer) Text(row, col int, text string, style Style) {
+	// A mirrored board moves the text as a whole rather than reversing it
+	col = f.column(col, Width(text))
+	for _, g := range Glyphs(text) {
+		if g.Width > 0 {
+			f.put(col+g.Col, row, cell{glyph: g.Text, style: style}, g.Width)
+		}
+	}
+}
+
+// Backdrop draws text as scenery behind the rest of the board. It goes
+// down first; its cells still count as blank, and plain spaces drawn over
+// them leave it showing, so sprites only hide the scenery they cover.
+func (f *FrameBuffer) Backdrop(row, col int, text string, style Style) {
+	col = f.column(col, Width(text))
+	for _, g := range Glyphs(text) {
+		if g.Width > 0 && g.Text != " " {
+			f.put(col+g.Col, row, cell{glyph: g.Text, style: style, back: true}, g.Width)
+		}
+	}
+}
+
+// column is where something width cells wide drawn at column x lands
+func (f *FrameBuffer) column(x, width int) int {
+	x -= f.scroll
+	if !f.mirrored {
+		return x
+	}
+	return f.width - x - max(width, 1)
+}
+
+func (f *FrameBuffer) put(x, y int, c cell, width int) {
+	if !f.InBounds(x, y) || x+width > f.width {
+		return
+	}
+	row := f.cells[y*f.width : (y+1)*f.width]
+	if row[x].back && c.glyph == " " && c.style.BG == nil && !c.raw {
+		return
+	}
+
+	// Overwriting part of a wide glyph blanks the rest of it
+	start := x
+	for start > 0 && row[start].covered {
+		start--
+	}
+	for i := start; i < x; i++ {
+		row[i] = blankCell
+	}
+	for i := x + max(width, 1); i < f.width && row[i].covered; i++ {
+		row[i] = blankCell
+	}
+
+	row[x] = c
+	for i := 1; i < width; i++ {
+		row[x+i] = cell{covered: true}
+	}
+}
+
+// Render joins the board into lines, reusing rows that didn't change
+func (f *FrameBuffer) Render() string {
+	var out strings.Builder
+	for y := range f.height {
+		row := f.cells[y*f.width : (y+1)*f.width]
+		if !slices.Equal(row, f.prev[y*f.width:(y+1)*f.width]) {
+			f.rows[y] = f.renderRow(row)
+		}
+		out.WriteString(f.rows[y])
+		out.WriteByte('\n')
+	}
+	copy(f.prev, f.cells)
+	return out.String()
+}
+
+func (f *FrameBuffer) renderRow(row []cell) string {
+	var b strings.Builder
+	for _, c := range row {
+		switch {
+		case c.covered:
+		case c.raw || c == blankCell:
+			b.WriteString(c.glyph)
+		default:
+			s, ok := f.styled[c]
+			if !ok {
+				s = c.style.style().Render(c.glyph)
+				f.styled[c] = s
+			}
+			b.WriteString(s)
+		}
+	}
+	return b.String()
+}
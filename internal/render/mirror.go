@@ -0,0 +1,58 @@
+package render
+
+import (
+	"slices"
+	"strings"
+	"unicode/utf8"
+)
+
+// mirrorPairs lists glyphs that turn into each other when flipped
+var mirrorPairs = [][2]rune{
+	{'<', '>'}, {'(', ')'}, {'[', ']'}, {'{', '}'}, {'/', '\\'},
+	{'◁', '▷'}, {'◀', '▶'}, {'≺', '≻'}, {'←', '→'}, {'⇤', '⇥'},
+	{'➤', '⮜'}, {'╱', '╲'}, {'«', '»'}, {'⟨', '⟩'}, {'┌', '┐'}, {'└', '┘'},
+}
+
+// mirrored maps each glyph in mirrorPairs to its partner
+var mirrored = func() map[rune]rune {
+	m := make(map[rune]rune, 2*len(mirrorPairs))
+	for _, p := range mirrorPairs {
+		m[p[0]], m[p[1]] = p[1], p[0]
+	}
+	return m
+}()
+
+// brailleBase is the first braille pattern; the low byte holds its dots
+const brailleBase = 0x2800
+
+// mirrorGlyph flips a glyph left to right: its grapheme clusters are
+// reversed and each is swapped for its mirror image where there is one.
+// Modifiers and combining marks stay with their cluster.
+func mirrorGlyph(glyph string) string {
+	var clusters []string
+	for _, g := range Glyphs(glyph) {
+		r, size := utf8.DecodeRuneInString(g.Text)
+		if m, ok := mirrored[r]; ok {
+			r = m
+		} else if r >= brailleBase && r <= brailleBase+0xFF {
+			r = brailleBase + mirrorDots(r-brailleBase)
+		}
+		clusters = append(clusters, string(r)+g.Text[size:])
+	}
+	slices.Reverse(clusters)
+	return strings.Join(clusters, "")
+}
+
+// mirrorDots swaps a braille cell's left and right dot columns
+func mirrorDots(dots rune) rune {
+	var out rune
+	for row := range brailleDots[0] {
+		if dots&brailleDots[0][row] != 0 {
+			out |= brailleDots[1][row]
+		}
+		if dots&brailleDots[1][row] != 0 {
+			out |= brailleDots[0][row]
+		}
+	}
+	return out
+}
@@ -0,0 +1,116 @@
+package render
+
+import (
+	"math"
+
+	"github.com/ashX04/gobowarrow/internal/config"
+	"github.com/ashX04/gobowarrow/internal/entities"
+	"github.com/ashX04/gobowarrow/internal/physics"
+)
+
+// Renderer draws the fast-moving parts of the board: arrows and the
+// dotted trails that follow them. Sprites always use whole cells.
+type Renderer interface {
+	// DrawArrow draws an arrow in flight
+	DrawArrow(f *FrameBuffer, a entities.Arrow, style Style)
+	// DrawPath dots a path through points given in board cells,
+	// leaving cells that already hold something alone
+	DrawPath(f *FrameBuffer, points []physics.Vec, style Style)
+}
+
+// New returns the renderer with the given config name, falling back
+// to the cell renderer for names it doesn't know
+func New(name string) Renderer {
+	if name == config.RendererBraille {
+		return brailleRenderer{}
+	}
+	return cellRenderer{}
+}
+
+// cellRenderer draws one glyph per terminal cell
+type cellRenderer struct{}
+
+func (cellRenderer) DrawArrow(f *FrameBuffer, a entities.Arrow, style Style) {
+	x, y := a.Cell()
+	f.Set(x, y, a.Symbol, style)
+}
+
+func (cellRenderer) DrawPath(f *FrameBuffer, points []physics.Vec, style Style) {
+	for _, p := range points {
+		x, y := p.Cell()
+		if f.Blank(x, y) {
+			f.Set(x, y, "·", style)
+		}
+	}
+}
+
+// brailleRenderer plots onto a virtual grid two dots wide and four tall
+// per cell, so arrows and trails move smoothly between cells
+type brailleRenderer struct{}
+
+// arrowLength is how many cells long a braille arrow's shaft is
+const arrowLength = 3
+
+func (brailleRenderer) DrawArrow(f *FrameBuffer, a entities.Arrow, style Style) {
+	speed := a.Speed()
+	if speed == 0 {
+		return
+	}
+	// Trace the shaft back from the tip along the direction of flight
+	dir := physics.Vec{X: a.Body.Vel.X / speed, Y: a.Body.Vel.Y / speed}
+	tail := a.Body.Pos.Add(dir.Scale(-arrowLength))
+	var c brailleCanvas
+	c.line(tail, a.Body.Pos)
+	c.draw(f, style, false)
+}
+
+func (brailleRenderer) DrawPath(f *FrameBuffer, points []physics.Vec, style Style) {
+	var c brailleCanvas
+	for _, p := range points {
+		c.plot(p)
+	}
+	c.draw(f, style, true)
+}
+
+// brailleDots maps a dot's column and row within a cell to its bit
+var brailleDots = [2][4]rune{
+	{0x01, 0x02, 0x04, 0x40},
+	{0x08, 0x10, 0x20, 0x80},
+}
+
+// brailleCanvas accumulates dots per board cell
+type brailleCanvas map[[2]int]rune
+
+// plot sets the dot under p, given in board cells
+func (c *brailleCanvas) plot(p physics.Vec) {
+	if *c == nil {
+		*c = make(brailleCanvas)
+	}
+	px, py := int(math.Floor(p.X*2)), int(math.Floor(p.Y*4))
+	if px < 0 || py < 0 {
+		return
+	}
+	cell := [2]int{px / 2, py / 4}
+	(*c)[cell] |= brailleDots[px%2][py%4]
+}
+
+// line plots dots every quarter cell from a to b
+func (c *brailleCanvas) line(a, b physics.Vec) {
+	steps := max(int(math.Hypot(b.X-a.X, b.Y-a.Y)*4), 1)
+	for i := range steps + 1 {
+		t := float64(i) / float64(steps)
+		c.plot(physics.Vec{X: a.X + (b.X-a.X)*t, Y: a.Y + (b.Y-a.Y)*t})
+	}
+}
+
+// draw writes every touched cell to the board; with blankOnly it skips
+// cells that already hold something
+func (c brailleCanvas) draw(f *FrameBuffer, style Style, blankOnly bool) {
+	for cell, dots := range c {
+		x, y := cell[0], cell[1]
+		if blankOnly && !f.Blank(x, y) {
+			continue
+		}
+		f.Set(x, y, string(0x2800+dots), style)
+	}
+}
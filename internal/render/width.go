@@ -0,0 +1,40 @@
+package render
+
+import "github.com/rivo/uniseg"
+
+// Glyph is one grapheme cluster of a line: what the player sees as a
+// single character, such as an emoji with its modifiers or a letter with
+// its accents
+type Glyph struct {
+	Text  string
+	Col   int // column it starts at
+	Width int // columns it covers; 0 for a lone combining mark
+}
+
+// Glyphs splits line into the glyphs it is drawn as, with their columns
+func Glyphs(line string) []Glyph {
+	var glyphs []Glyph
+	col := 0
+	g := uniseg.NewGraphemes(line)
+	for g.Next() {
+		w := g.Width()
+		glyphs = append(glyphs, Glyph{Text: g.Str(), Col: col, Width: w})
+		col += w
+	}
+	return glyphs
+}
+
+// Width is how many columns s covers in a terminal
+func Width(s string) int {
+	return uniseg.StringWidth(s)
+}
+
+// ArtWidth is how many columns the widest line of art covers, so sprites
+// with wide glyphs get a hitbox as wide as what's drawn
+func ArtWidth(art []string) int {
+	width := 0
+	for _, line := range art {
+		width = max(width, Width(line))
+	}
+	return width
+}
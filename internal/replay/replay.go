@@ -0,0 +1,120 @@
+// Package replay records what the archer did during a run so it can be
+// played back later, such as the ghost of a player's best run.
+package replay
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+)
+
+// Shot is one arrow leaving the bow
+type Shot struct {
+	Tick   int     `json:"tick"`
+	X      float64 `json:"x"`
+	Y      float64 `json:"y"`
+	VX     float64 `json:"vx"` // launch velocity in cells per second
+	VY     float64 `json:"vy"`
+	Symbol string  `json:"symbol"`
+}
+
+// Run is a recorded run. Balloons aren't stored: replaying the same mode
+// and seed spawns them again.
+type Run struct {
+	Mode   string `json:"mode"`
+	Seed   int64  `json:"seed"`
+	Score  int    `json:"score"`
+	Archer []int  `json:"archer"` // archer's row at every tick
+	Shots  []Shot `json:"shots"`  // in the order they were fired
+
+	// Columns is the archer's column at every tick. Runs recorded before
+	// the archer could move sideways don't have it; they stood in column 0.
+	Columns []int `json:"columns,omitempty"`
+}
+
+// At returns the archer's column and row at tick, or false once the run
+// has ended
+func (r Run) At(tick int) (col, row int, ok bool) {
+	if tick < 0 || tick >= len(r.Archer) {
+		return 0, 0, false
+	}
+	if tick < len(r.Columns) {
+		col = r.Columns[tick]
+	}
+	return col, r.Archer[tick], true
+}
+
+// Ghosts keeps the best run for each mode and seed
+type Ghosts []Run
+
+// DefaultPath returns the ghosts file location under the user config dir
+func DefaultPath() (string, error) {
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "bowarrow", "ghosts.json"), nil
+}
+
+// Load reads ghosts from disk. A missing file yields none.
+func Load(path string) (Ghosts, error) {
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return Ghosts{}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var g Ghosts
+	if err := json.Unmarshal(data, &g); err != nil {
+		return nil, err
+	}
+	return g, nil
+}
+
+// Save writes the ghosts to disk, creating the parent directory if needed
+func (g Ghosts) Save(path string) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+	data, err := json.Marshal(g)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, 0o644)
+}
+
+// Best returns the stored run for mode and seed
+func (g Ghosts) Best(mode string, seed int64) (Run, bool) {
+	for _, r := range g {
+		if r.Mode == mode && r.Seed == seed {
+			return r, true
+		}
+	}
+	return Run{}, false
+}
+
+// Record returns ghosts with r kept if it beats the stored run for its
+// mode and seed, and whether it did
+func (g Ghosts) Record(r Run) (Ghosts, bool) {
+	if r.Score <= 0 {
+		return g, false
+	}
+	for i, old := range g {
+		if old.Mode != r.Mode || old.Seed != r.Seed {
+			continue
+		}
+		if r.Score <= old.Score {
+			return g, false
+		}
+		out := make(Ghosts, len(g))
+		copy(out, g)
+		out[i] = r
+		return out, true
+	}
+	out := make(Ghosts, len(g), len(g)+1)
+	copy(out, g)
+	return append(out, r), true
+}
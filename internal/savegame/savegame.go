@@ -0,0 +1,216 @@
+// Package savegame keeps a run the player quit part way through, so the
+// next launch can offer to pick it up where they left off.
+package savegame
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+
+	"github.com/ashX04/gobowarrow/internal/anim"
+	"github.com/ashX04/gobowarrow/internal/entities"
+	"github.com/ashX04/gobowarrow/internal/replay"
+)
+
+// Version is the save format written by this build. Bump it whenever a
+// change to Run, or to how the game reads it, would resume an older save
+// wrongly; saves of any other version are passed over.
+const Version = 1
+
+// MaxDraws is the most RNG draws a save may say its run made. Resuming
+// draws them all again, so an edited or corrupt count could keep it busy
+// for good. Real runs make a handful a tick; winding to this many takes
+// about a second.
+const MaxDraws = 1 << 28
+
+// Run is everything needed to carry on a run. The board's size, the
+// player's settings and anything purely for show aren't kept: they come
+// from the launch that resumes it.
+type Run struct {
+	Version    int    `json:"version"`
+	Mode       string `json:"mode"`
+	Difficulty string `json:"difficulty"`
+
+	// The RNG is kept as its seed and how many values had been drawn
+	// from it, and wound forward to the same place on resume
+	Seed  int64  `json:"seed"`
+	Draws uint64 `json:"draws"`
+
+	// Timers are all counted in ticks
+	Timer       int    `json:"timer"`
+	MovedAt     int    `json:"moved_at"`
+	ReloadTicks int    `json:"reload_ticks"`
+	HitStop     int    `json:"hit_stop"`
+	Banner      string `json:"banner,omitempty"` // spawns hold while it shows
+	BannerTicks int    `json:"banner_ticks"`
+
+	Score       int   `json:"score"`
+	Shots       int   `json:"shots"`
+	Hits        int   `json:"hits"`
+	Combo       int   `json:"combo"`
+	BestCombo   int   `json:"best_combo"`
+	Strays      int   `json:"strays"`
+	Escaped     int   `json:"escaped"`
+	WaveEscaped int   `json:"wave_escaped"`
+	Lives       int   `json:"lives"`
+	Coins       int   `json:"coins"`
+	Upgrades    []int `json:"upgrades"`
+
+	Wave       Wave     `json:"wave"`
+	Archer     int      `json:"archer"`
+	ArcherCol  int      `json:"archer_col"`
+	Aim        int      `json:"aim"`
+	Selected   int      `json:"selected"`
+	Quiver     []int    `json:"quiver"`
+	ArrowsLeft int      `json:"arrows_left"`
+	Effects    []Effect `json:"effects,omitempty"`
+	Weather    string   `json:"weather,omitempty"`
+
+	Arrows   []entities.Arrow  `json:"arrows,omitempty"`
+	Balloons []Balloon         `json:"balloons,omitempty"`
+	Birds    []Bird            `json:"birds,omitempty"`
+	Boss     *Boss             `json:"boss,omitempty"`
+	Targets  []entities.Target `json:"targets,omitempty"`
+
+	Typed   string `json:"typed,omitempty"` // typing mode's letters so far
+	Typos   int    `json:"typos,omitempty"`
+	Problem []int  `json:"problem,omitempty"` // math mode's sum: both numbers and the operation
+	Right   []int  `json:"right,omitempty"`   // math mode's report card, by operation
+	Wrong   []int  `json:"wrong,omitempty"`
+
+	Record      replay.Run       `json:"record"` // the run so far, for its ghost
+	GhostShot   int              `json:"ghost_shot"`
+	GhostArrows []entities.Arrow `json:"ghost_arrows,omitempty"`
+}
+
+// Wave is the wave being played
+type Wave struct {
+	Number  int     `json:"number"`
+	Quota   int     `json:"quota"`
+	Spawned int     `json:"spawned"`
+	Speed   float64 `json:"speed"`
+	Density float64 `json:"density"`
+}
+
+// Effect is a power-up still running
+type Effect struct {
+	Kind  entities.PowerUp `json:"kind"`
+	Ticks int              `json:"ticks"`
+}
+
+// Balloon is a balloon on the board and how far through its animation it
+// is. Which animation that is follows from whether it has popped.
+type Balloon struct {
+	entities.Balloon
+	Playback anim.Position `json:"playback"`
+}
+
+// Bird is a bird on the board and how far through its wing beats it is
+type Bird struct {
+	entities.Bird
+	Playback anim.Position `json:"playback"`
+}
+
+// Boss is the boss on the board
+type Boss struct {
+	X          float64 `json:"x"`
+	Y          float64 `json:"y"`
+	DX         int     `json:"dx"`
+	DY         int     `json:"dy"`
+	HP         int     `json:"hp"`
+	FlashTicks int     `json:"flash_ticks"`
+}
+
+// DefaultPath returns the save file location under the user config dir
+func DefaultPath() (string, error) {
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "bowarrow", "save.json"), nil
+}
+
+// Load reads the saved run from disk. It returns nil when there is none,
+// or when it was saved in another version's format.
+func Load(path string) (*Run, error) {
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var r Run
+	if err := json.Unmarshal(data, &r); err != nil {
+		return nil, err
+	}
+	if r.Version != Version {
+		return nil, nil
+	}
+	if r.Draws > MaxDraws {
+		return nil, fmt.Errorf("%d RNG draws is more than any run makes", r.Draws)
+	}
+	return &r, nil
+}
+
+// Kinds is how many kinds of arrow, power-up and math operation the game
+// has. A run names them by number, so these are what Check holds it to.
+type Kinds struct {
+	Arrows   int
+	PowerUps int // none included
+	Ops      int
+}
+
+// Check makes sure every kind r names by number is one of k's, so an
+// edited or corrupt save is turned down rather than crashing the run
+func (r Run) Check(k Kinds) error {
+	if r.Selected < 0 || r.Selected >= k.Arrows {
+		return fmt.Errorf("selected arrow %d is not one of the %d kinds", r.Selected, k.Arrows)
+	}
+	for _, a := range slices.Concat(r.Arrows, r.GhostArrows) {
+		if a.Kind < 0 || int(a.Kind) >= k.Arrows {
+			return fmt.Errorf("arrow kind %d is not one of the %d kinds", a.Kind, k.Arrows)
+		}
+	}
+	for _, e := range r.Effects {
+		if e.Kind < 0 || int(e.Kind) >= k.PowerUps {
+			return fmt.Errorf("effect kind %d is not one of the %d kinds", e.Kind, k.PowerUps)
+		}
+	}
+	for _, b := range r.Balloons {
+		if b.PowerUp < 0 || int(b.PowerUp) >= k.PowerUps {
+			return fmt.Errorf("balloon power-up %d is not one of the %d kinds", b.PowerUp, k.PowerUps)
+		}
+	}
+	if len(r.Problem) == 3 && (r.Problem[2] < 0 || r.Problem[2] >= k.Ops) {
+		return fmt.Errorf("math operation %d is not one of the %d kinds", r.Problem[2], k.Ops)
+	}
+	return nil
+}
+
+// Save writes the run to disk in the current format, creating the parent
+// directory if needed
+func (r Run) Save(path string) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+	r.Version = Version
+	data, err := json.MarshalIndent(r, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, 0o644)
+}
+
+// Remove deletes the saved run once it has been resumed. There being none
+// is fine.
+func Remove(path string) error {
+	if err := os.Remove(path); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,75 @@
+package savegame
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/ashX04/gobowarrow/internal/entities"
+)
+
+func TestLoadRoundTrip(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "save.json")
+	if err := (Run{Mode: "classic", Seed: 7, Draws: 1234, Score: 42}).Save(path); err != nil {
+		t.Fatal(err)
+	}
+	r, err := Load(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if r == nil || r.Seed != 7 || r.Draws != 1234 || r.Score != 42 {
+		t.Errorf("Load() = %+v, want the run saved", r)
+	}
+}
+
+func TestLoadRejectsTooManyDraws(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "save.json")
+	if err := (Run{Mode: "classic", Draws: MaxDraws + 1}).Save(path); err != nil {
+		t.Fatal(err)
+	}
+	if r, err := Load(path); err == nil {
+		t.Errorf("Load() = %+v, want an error for %d draws", r, uint64(MaxDraws+1))
+	}
+}
+
+func TestLoadMissingFile(t *testing.T) {
+	r, err := Load(filepath.Join(t.TempDir(), "save.json"))
+	if r != nil || err != nil {
+		t.Errorf("Load() = %v, %v; want nil, nil", r, err)
+	}
+}
+
+func TestLoadOtherVersion(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "save.json")
+	if err := os.WriteFile(path, []byte(`{"version": 999, "draws": 1}`), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	r, err := Load(path)
+	if r != nil || err != nil {
+		t.Errorf("Load() = %v, %v; want nil, nil", r, err)
+	}
+}
+
+func TestCheck(t *testing.T) {
+	kinds := Kinds{Arrows: 4, PowerUps: 6, Ops: 3}
+	for _, tt := range []struct {
+		name string
+		run  Run
+		ok   bool
+	}{
+		{"fine", Run{Selected: 3, Effects: []Effect{{Kind: 5}}, Problem: []int{2, 3, 2}}, true},
+		{"selected arrow", Run{Selected: 9}, false},
+		{"negative selected arrow", Run{Selected: -1}, false},
+		{"arrow in flight", Run{Arrows: []entities.Arrow{{Kind: 4}}}, false},
+		{"ghost arrow", Run{GhostArrows: []entities.Arrow{{Kind: -1}}}, false},
+		{"effect", Run{Effects: []Effect{{Kind: 99}}}, false},
+		{"balloon power-up", Run{Balloons: []Balloon{{Balloon: entities.Balloon{PowerUp: 6}}}}, false},
+		{"math operation", Run{Problem: []int{2, 3, 3}}, false},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.run.Check(kinds); (err == nil) != tt.ok {
+				t.Errorf("Check() = %v, want ok %v", err, tt.ok)
+			}
+		})
+	}
+}
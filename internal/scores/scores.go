@@ -0,0 +1,127 @@
+// Package scores stores the local high-score table.
+package scores
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+)
+
+// MaxEntries is how many scores the table keeps per mode
+const MaxEntries = 10
+
+// LegacyMode is assigned to entries saved before modes existed
+const LegacyMode = "classic"
+
+// Entry is a single high-score record
+type Entry struct {
+	Mode     string    `json:"mode"`
+	Initials string    `json:"initials"`
+	Score    int       `json:"score"`
+	Time     time.Time `json:"time"`
+}
+
+// Table is a list of entries for all modes, sorted from best to worst
+type Table []Entry
+
+// DefaultPath returns the scores file location under the user config dir
+func DefaultPath() (string, error) {
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "bowarrow", "scores.json"), nil
+}
+
+// Load reads a table from disk. A missing file yields an empty table.
+func Load(path string) (Table, error) {
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return Table{}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var t Table
+	if err := json.Unmarshal(data, &t); err != nil {
+		return nil, err
+	}
+	for i := range t {
+		if t[i].Mode == "" {
+			t[i].Mode = LegacyMode
+		}
+	}
+	t.sort()
+	return t.trim(), nil
+}
+
+// Save writes the table to disk, creating the parent directory if needed
+func (t Table) Save(path string) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+	data, err := json.MarshalIndent(t, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, 0o644)
+}
+
+// ForMode returns the entries recorded for one mode
+func (t Table) ForMode(mode string) Table {
+	out := Table{}
+	for _, e := range t {
+		if e.Mode == mode {
+			out = append(out, e)
+		}
+	}
+	return out
+}
+
+// Qualifies reports whether score would make it onto the mode's table
+func (t Table) Qualifies(mode string, score int) bool {
+	if score <= 0 {
+		return false
+	}
+	ranked := t.ForMode(mode)
+	if len(ranked) < MaxEntries {
+		return true
+	}
+	return score > ranked[len(ranked)-1].Score
+}
+
+// Add returns a new table with e inserted in rank order
+func (t Table) Add(e Entry) Table {
+	out := make(Table, len(t), len(t)+1)
+	copy(out, t)
+	out = append(out, e)
+	out.sort()
+	return out.trim()
+}
+
+// sort orders by score descending; ties go to the earlier entry
+func (t Table) sort() {
+	sort.SliceStable(t, func(i, j int) bool {
+		if t[i].Score != t[j].Score {
+			return t[i].Score > t[j].Score
+		}
+		return t[i].Time.Before(t[j].Time)
+	})
+}
+
+// trim keeps the best MaxEntries per mode; t must already be sorted
+func (t Table) trim() Table {
+	counts := make(map[string]int)
+	out := t[:0]
+	for _, e := range t {
+		if counts[e.Mode] < MaxEntries {
+			counts[e.Mode]++
+			out = append(out, e)
+		}
+	}
+	return out
+}
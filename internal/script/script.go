@@ -0,0 +1,356 @@
+// Package script runs the Lua a level file can carry: extra spawns, its
+// own win and lose conditions, and events scheduled for set times. Scripts
+// only see the game through World, and get none of Lua's file, OS or
+// module functions.
+package script
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strings"
+	"time"
+
+	lua "github.com/yuin/gopher-lua"
+)
+
+// Sandbox limits. A tick's timers and on_tick share one budget of
+// instructions, as does the top level when the script loads, and are
+// stopped once it's spent rather than after so long, so a seeded run
+// plays out the same on a slow machine as on a fast one. The stacks are
+// capped, as are the timers and the string string.rep makes, the quickest
+// ways for a script to eat memory or time.
+const (
+	tickBudget    = 250_000   // Lua instructions one tick, or the top level, may run
+	callStackSize = 200       // nested Lua calls
+	registrySize  = 1024      // stack slots to start with, and to grow by
+	registryMax   = 64 * 1024 // stack slots at most
+	maxRep        = 64 * 1024 // bytes string.rep may return
+	maxTimers     = 256       // timers set with at and every, waiting at once
+)
+
+// newState makes a Lua state within the sandbox's limits
+func newState() *lua.LState {
+	return lua.NewState(lua.Options{
+		SkipOpenLibs:     true,
+		CallStackSize:    callStackSize,
+		RegistrySize:     registrySize,
+		RegistryMaxSize:  registryMax,
+		RegistryGrowStep: registrySize,
+	})
+}
+
+// World is what a script can see and change of the run it's part of
+type World interface {
+	Spawn(kind string, count int) error // kind is "balloon", "golden" or a sprite name
+	Boss()
+	BossHP() int // 0 when there's no boss
+	Effect(name string) error
+	Banner(text string)
+	Score() int
+	AddScore(points int)
+	Escaped() int
+	Random(n int) int // uniform in [1, n], from the run's seeded RNG
+}
+
+// Script is one run's Lua state. Each run gets its own, so a restart
+// starts the script over.
+type Script struct {
+	L        *lua.LState
+	world    World // set for the length of each call in
+	tickRate int
+	tick     int
+	timers   []timer
+	won      bool
+	lost     bool
+}
+
+// timer is a function set to run at a tick, and again every so many
+// ticks after if it repeats
+type timer struct {
+	due   int
+	every int // 0 runs it once
+	fn    *lua.LFunction
+}
+
+// Check compiles src without running it, to catch syntax errors when the
+// level is loaded
+func Check(name, src string) error {
+	L := newState()
+	defer L.Close()
+	_, err := L.Load(strings.NewReader(src), name)
+	return err
+}
+
+// Load runs src's top level in a fresh sandbox. tickRate converts the
+// seconds scripts work in to the game's ticks.
+func Load(name, src string, tickRate int, w World) (*Script, error) {
+	s := &Script{L: newState(), tickRate: tickRate}
+	s.sandbox()
+	fn, err := s.L.Load(strings.NewReader(src), name)
+	if err != nil {
+		s.L.Close()
+		return nil, err
+	}
+	if err := s.call(w, fn, &budget{left: tickBudget}); err != nil {
+		s.L.Close()
+		return nil, err
+	}
+	return s, nil
+}
+
+// sandbox opens the safe parts of Lua's standard library and adds the
+// game's API
+func (s *Script) sandbox() {
+	L := s.L
+	for _, lib := range []struct {
+		name string
+		open lua.LGFunction
+	}{
+		{lua.BaseLibName, lua.OpenBase},
+		{lua.TabLibName, lua.OpenTable},
+		{lua.StringLibName, lua.OpenString},
+		{lua.MathLibName, lua.OpenMath},
+	} {
+		L.Push(L.NewFunction(lib.open))
+		L.Push(lua.LString(lib.name))
+		L.Call(1, 0)
+	}
+	// Nothing that reaches the disk or the terminal, and no randomness but
+	// the run's own, so seeded runs replay the same
+	for _, name := range []string{"dofile", "loadfile", "load", "loadstring", "require", "module", "print", "collectgarbage", "_printregs"} {
+		L.SetGlobal(name, lua.LNil)
+	}
+	mathlib := L.GetGlobal("math").(*lua.LTable)
+	mathlib.RawSetString("random", lua.LNil)
+	mathlib.RawSetString("randomseed", lua.LNil)
+	strlib := L.GetGlobal("string").(*lua.LTable)
+	strlib.RawSetString("rep", L.NewFunction(rep))
+
+	L.SetGlobal("at", L.NewFunction(s.at))
+	L.SetGlobal("every", L.NewFunction(s.every))
+	L.SetGlobal("game", L.SetFuncs(L.NewTable(), map[string]lua.LGFunction{
+		"spawn":     s.spawn,
+		"boss":      s.boss,
+		"boss_hp":   s.bossHP,
+		"effect":    s.effect,
+		"banner":    s.banner,
+		"score":     s.score,
+		"add_score": s.addScore,
+		"escaped":   s.escaped,
+		"random":    s.random,
+		"time":      s.time,
+		"win":       s.win,
+		"lose":      s.lose,
+	}))
+}
+
+// Tick moves the script on to tick t of the run, running any timers due
+// and then its on_tick function, if it has one. They all share the one
+// tick's budget.
+func (s *Script) Tick(w World, t int) error {
+	s.tick = t
+	b := &budget{left: tickBudget}
+	for i := 0; i < len(s.timers); i++ {
+		tm := s.timers[i]
+		if tm.due > t {
+			continue
+		}
+		if tm.every > 0 {
+			s.timers[i].due += tm.every
+		} else {
+			s.timers = append(s.timers[:i], s.timers[i+1:]...)
+			i--
+		}
+		if err := s.call(w, tm.fn, b); err != nil {
+			return err
+		}
+	}
+	if fn, ok := s.L.GetGlobal("on_tick").(*lua.LFunction); ok {
+		return s.call(w, fn, b)
+	}
+	return nil
+}
+
+// Won reports whether the script has called game.win()
+func (s *Script) Won() bool {
+	return s.won
+}
+
+// Lost reports whether the script has called game.lose()
+func (s *Script) Lost() bool {
+	return s.lost
+}
+
+// call runs fn against w on what's left of b
+func (s *Script) call(w World, fn *lua.LFunction, b *budget) error {
+	s.world = w
+	s.L.SetContext(b)
+	defer func() {
+		s.L.RemoveContext()
+		s.world = nil
+	}()
+	err := s.L.CallByParam(lua.P{Fn: fn, NRet: 0, Protect: true})
+	if err != nil && b.left == 0 {
+		return errBudget
+	}
+	// Just the message, without Lua's stack traceback
+	var apiErr *lua.ApiError
+	if errors.As(err, &apiErr) {
+		return errors.New(apiErr.Object.String())
+	}
+	return err
+}
+
+// errBudget is the error a call stopped for running out of budget gets
+var errBudget = fmt.Errorf("ran past its budget of %d instructions", tickBudget)
+
+// spent is the channel budget's Done returns once it has run out
+var spent = func() chan struct{} {
+	c := make(chan struct{})
+	close(c)
+	return c
+}()
+
+// budget is the context calls into a script run under. The Lua VM asks
+// for Done before every instruction, so it counts those down and reports
+// itself done once there are none left.
+type budget struct{ left int }
+
+func (b *budget) Done() <-chan struct{} {
+	if b.left == 0 {
+		return spent
+	}
+	b.left--
+	return nil
+}
+
+func (b *budget) Err() error {
+	if b.left == 0 {
+		return errBudget
+	}
+	return nil
+}
+
+func (b *budget) Deadline() (time.Time, bool) { return time.Time{}, false }
+func (b *budget) Value(any) any               { return nil }
+
+// rep is string.rep, refusing to make a string longer than maxRep
+func rep(L *lua.LState) int {
+	str, n := L.CheckString(1), L.CheckInt(2)
+	if n > 0 && len(str) > maxRep/n {
+		L.RaiseError("string.rep: result longer than %d bytes", maxRep)
+	}
+	L.Push(lua.LString(strings.Repeat(str, max(n, 0))))
+	return 1
+}
+
+// ticks converts seconds to ticks, never less than one
+func (s *Script) ticks(seconds float64) int {
+	return max(int(math.Round(seconds*float64(s.tickRate))), 1)
+}
+
+// at(seconds, fn) runs fn once, that many seconds into the run
+func (s *Script) at(L *lua.LState) int {
+	due := int(math.Round(float64(L.CheckNumber(1)) * float64(s.tickRate)))
+	fn := L.CheckFunction(2)
+	s.checkTimers(L)
+	s.timers = append(s.timers, timer{due: max(due, s.tick+1), fn: fn})
+	return 0
+}
+
+// every(seconds, fn) runs fn every that many seconds
+func (s *Script) every(L *lua.LState) int {
+	n := s.ticks(float64(L.CheckNumber(1)))
+	fn := L.CheckFunction(2)
+	s.checkTimers(L)
+	s.timers = append(s.timers, timer{due: s.tick + n, every: n, fn: fn})
+	return 0
+}
+
+// checkTimers refuses another timer once maxTimers are waiting
+func (s *Script) checkTimers(L *lua.LState) {
+	if len(s.timers) >= maxTimers {
+		L.RaiseError("more than %d timers set", maxTimers)
+	}
+}
+
+// game.spawn(kind[, count])
+func (s *Script) spawn(L *lua.LState) int {
+	if err := s.world.Spawn(L.CheckString(1), L.OptInt(2, 1)); err != nil {
+		L.ArgError(1, err.Error())
+	}
+	return 0
+}
+
+// game.boss()
+func (s *Script) boss(L *lua.LState) int {
+	s.world.Boss()
+	return 0
+}
+
+// game.boss_hp()
+func (s *Script) bossHP(L *lua.LState) int {
+	L.Push(lua.LNumber(s.world.BossHP()))
+	return 1
+}
+
+// game.effect(name)
+func (s *Script) effect(L *lua.LState) int {
+	if err := s.world.Effect(L.CheckString(1)); err != nil {
+		L.ArgError(1, err.Error())
+	}
+	return 0
+}
+
+// game.banner(text)
+func (s *Script) banner(L *lua.LState) int {
+	s.world.Banner(L.CheckString(1))
+	return 0
+}
+
+// game.score()
+func (s *Script) score(L *lua.LState) int {
+	L.Push(lua.LNumber(s.world.Score()))
+	return 1
+}
+
+// game.add_score(points)
+func (s *Script) addScore(L *lua.LState) int {
+	s.world.AddScore(L.CheckInt(1))
+	return 0
+}
+
+// game.escaped()
+func (s *Script) escaped(L *lua.LState) int {
+	L.Push(lua.LNumber(s.world.Escaped()))
+	return 1
+}
+
+// game.random(n)
+func (s *Script) random(L *lua.LState) int {
+	n := L.CheckInt(1)
+	if n < 1 {
+		L.ArgError(1, "must be at least 1")
+	}
+	L.Push(lua.LNumber(s.world.Random(n)))
+	return 1
+}
+
+// game.time() is the seconds since the run started
+func (s *Script) time(L *lua.LState) int {
+	L.Push(lua.LNumber(float64(s.tick) / float64(s.tickRate)))
+	return 1
+}
+
+// game.win()
+func (s *Script) win(L *lua.LState) int {
+	s.won = true
+	return 0
+}
+
+// game.lose()
+func (s *Script) lose(L *lua.LState) int {
+	s.lost = true
+	return 0
+}
@@ -0,0 +1,108 @@
+package script
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+// world is a World that keeps score and counts spawns
+type world struct {
+	score  int
+	spawns int
+}
+
+func (w *world) Spawn(kind string, count int) error { w.spawns += count; return nil }
+func (w *world) Boss()                              {}
+func (w *world) BossHP() int                        { return 0 }
+func (w *world) Effect(name string) error           { return nil }
+func (w *world) Banner(text string)                 {}
+func (w *world) Score() int                         { return w.score }
+func (w *world) AddScore(points int)                { w.score += points }
+func (w *world) Escaped() int                       { return 0 }
+func (w *world) Random(n int) int                   { return 1 }
+
+func TestTimers(t *testing.T) {
+	w := &world{}
+	s, err := Load("timers", `
+		at(1, function() game.spawn("balloon", 2) end)
+		every(0.5, function() game.add_score(1) end)
+		function on_tick()
+			if game.score() >= 4 then game.win() end
+		end
+	`, 10, w)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for tick := 1; tick <= 20 && !s.Won(); tick++ {
+		if err := s.Tick(w, tick); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if w.spawns != 2 || w.score != 4 || !s.Won() {
+		t.Errorf("spawns %d score %d won %v, want 2 4 true", w.spawns, w.score, s.Won())
+	}
+}
+
+func TestSandboxLimits(t *testing.T) {
+	for _, tt := range []struct {
+		name, src, want string
+	}{
+		{"runaway loop", `while true do end`, errBudget.Error()},
+		{"deep recursion", `local function f() return 1 + f() end f()`, "stack overflow"},
+		{"huge string.rep", `local s = string.rep("x", 1e9)`, "longer than"},
+		{"huge stack", `string.byte(string.rep("x", 65536), 1, -1)`, "overflow"},
+		{"no file access", `dofile("/etc/passwd")`, "non-function"},
+		{"no own randomness", `math.random()`, "non-function"},
+		{"too many timers", `for i = 1, 1000 do every(1, function() end) end`, "timers"},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Load(tt.name, tt.src, 10, &world{})
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("Load() error = %v, want one mentioning %q", err, tt.want)
+			}
+		})
+	}
+}
+
+// TestBudgetIsCounted checks a call is stopped by how much it runs, not
+// how long it takes: the same loop gets exactly as far every time.
+func TestBudgetIsCounted(t *testing.T) {
+	var got []int
+	for range 3 {
+		w := &world{}
+		_, err := Load("count", `while true do game.add_score(1) end`, 10, w)
+		if !errors.Is(err, errBudget) {
+			t.Fatalf("Load() error = %v, want %v", err, errBudget)
+		}
+		got = append(got, w.score)
+	}
+	if got[0] == 0 || got[1] != got[0] || got[2] != got[0] {
+		t.Errorf("loops got to %v, want the same count each time", got)
+	}
+}
+
+// TestTickSharesOneBudget checks a tick's timers can't each run for a full
+// budget: together they're stopped once one tick's worth is spent.
+func TestTickSharesOneBudget(t *testing.T) {
+	w := &world{}
+	// Each timer runs for about a tenth of the budget
+	s, err := Load("timers", fmt.Sprintf(`
+		for i = 1, 200 do
+			every(0.1, function()
+				for j = 1, %d do end
+				game.add_score(1)
+			end)
+		end
+	`, tickBudget/10), 10, w)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := s.Tick(w, 1); !errors.Is(err, errBudget) {
+		t.Fatalf("Tick() error = %v, want %v", err, errBudget)
+	}
+	if w.score >= 10 {
+		t.Errorf("%d timers ran to the end in one tick, want fewer than 10", w.score)
+	}
+}
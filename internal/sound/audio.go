@@ -0,0 +1,93 @@
+//go:build audio
+
+package sound
+
+import (
+	"bytes"
+	"encoding/binary"
+	"math"
+	"math/rand"
+	"sync"
+	"time"
+
+	"github.com/ebitengine/oto/v3"
+)
+
+// sampleRate is the rate the effects are synthesized and played at
+const sampleRate = 22050
+
+// Audio plays short synthesized samples through the audio device
+type Audio struct {
+	ctx     *oto.Context
+	samples map[Effect][]byte
+}
+
+// device is the audio device, shared by the effects and the music since
+// oto only allows one context
+var device struct {
+	once sync.Once
+	ctx  *oto.Context
+	err  error
+}
+
+// openDevice opens the audio device the first time it's needed. It fails
+// if there is none, or if the system's audio library is missing.
+func openDevice() (*oto.Context, error) {
+	device.once.Do(func() {
+		ctx, ready, err := oto.NewContext(&oto.NewContextOptions{
+			SampleRate:   sampleRate,
+			ChannelCount: 1,
+			Format:       oto.FormatSignedInt16LE,
+		})
+		if err != nil {
+			device.err = err
+			return
+		}
+		<-ready
+		device.ctx = ctx
+	})
+	return device.ctx, device.err
+}
+
+// NewAudio plays effects through the audio device
+func NewAudio() (Player, error) {
+	ctx, err := openDevice()
+	if err != nil {
+		return nil, err
+	}
+	return &Audio{ctx: ctx, samples: map[Effect][]byte{
+		Shoot:    synth(0.08, 900, 1400, 0, 0.3),
+		Pop:      synth(0.12, 500, 200, 0.8, 0.5),
+		BossHit:  synth(0.25, 140, 60, 0.3, 0.7),
+		GameOver: synth(0.6, 440, 110, 0, 0.4),
+	}}, nil
+}
+
+// Play starts e's sample and returns while it plays
+func (a *Audio) Play(e Effect) {
+	p := a.ctx.NewPlayer(bytes.NewReader(a.samples[e]))
+	p.Play()
+	go func() {
+		for p.IsPlaying() {
+			time.Sleep(10 * time.Millisecond)
+		}
+		p.Close()
+	}()
+}
+
+// synth makes a sample seconds long that sweeps from one pitch to another
+// and fades out. noise mixes in that much static, for pops and thumps.
+func synth(seconds, from, to, noise, volume float64) []byte {
+	n := int(seconds * sampleRate)
+	rng := rand.New(rand.NewSource(1))
+	buf := make([]byte, 2*n)
+	phase := 0.0
+	for i := range n {
+		t := float64(i) / float64(n)
+		phase += 2 * math.Pi * (from + (to-from)*t) / sampleRate
+		v := (1-noise)*math.Sin(phase) + noise*(2*rng.Float64()-1)
+		v *= volume * (1 - t)
+		binary.LittleEndian.PutUint16(buf[2*i:], uint16(int16(v*math.MaxInt16)))
+	}
+	return buf
+}
@@ -0,0 +1,210 @@
+//go:build audio
+
+package sound
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math"
+	"os"
+
+	"github.com/ebitengine/oto/v3"
+)
+
+// musicState is what the track should be doing
+type musicState struct {
+	playing bool
+	volume  float64
+}
+
+// music loops a track on a goroutine of its own, the only one that touches
+// the player
+type music struct {
+	state musicState      // the last request, kept on the caller's side
+	want  chan musicState // holds at most the latest request
+}
+
+// NewMusic loops the 16-bit PCM WAV file at path through the audio device,
+// or the built-in tune if path is empty. It starts paused.
+func NewMusic(path string, volume float64) (Music, error) {
+	ctx, err := openDevice()
+	if err != nil {
+		return nil, err
+	}
+	track := tune()
+	if path != "" {
+		if track, err = loadWAV(path); err != nil {
+			return nil, fmt.Errorf("%s: %w", path, err)
+		}
+	}
+
+	m := &music{state: musicState{volume: volume}, want: make(chan musicState, 1)}
+	go m.run(ctx.NewPlayer(&loop{track: track}))
+	m.send()
+	return m, nil
+}
+
+// run carries out requests until the program exits
+func (m *music) run(p *oto.Player) {
+	for s := range m.want {
+		p.SetVolume(s.volume)
+		if s.playing {
+			p.Play()
+		} else {
+			p.Pause()
+		}
+	}
+}
+
+func (m *music) Resume() {
+	m.state.playing = true
+	m.send()
+}
+
+func (m *music) Pause() {
+	m.state.playing = false
+	m.send()
+}
+
+func (m *music) SetVolume(volume float64) {
+	m.state.volume = volume
+	m.send()
+}
+
+// send replaces any request the goroutine hasn't got to yet with the
+// current state. There's only one sender, so it never blocks.
+func (m *music) send() {
+	select {
+	case <-m.want:
+	default:
+	}
+	m.want <- m.state
+}
+
+// loop reads a track over and over
+type loop struct {
+	track []byte
+	pos   int
+}
+
+func (l *loop) Read(p []byte) (int, error) {
+	n := copy(p, l.track[l.pos:])
+	l.pos = (l.pos + n) % len(l.track)
+	return n, nil
+}
+
+// loadWAV reads a 16-bit PCM WAV file as mono samples at the device's rate
+func loadWAV(path string) ([]byte, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	if len(data) < 12 || string(data[:4]) != "RIFF" || string(data[8:12]) != "WAVE" {
+		return nil, errors.New("not a WAV file")
+	}
+
+	var channels, rate int
+	var pcm []byte
+	for off := 12; off+8 <= len(data); {
+		size := int(binary.LittleEndian.Uint32(data[off+4:]))
+		body := data[off+8 : min(off+8+size, len(data))]
+		switch string(data[off : off+4]) {
+		case "fmt ":
+			if len(body) < 16 {
+				return nil, errors.New("fmt chunk is too short")
+			}
+			format, bits := binary.LittleEndian.Uint16(body), binary.LittleEndian.Uint16(body[14:])
+			if format != 1 || bits != 16 {
+				return nil, errors.New("only 16-bit PCM WAV files are supported")
+			}
+			channels = int(binary.LittleEndian.Uint16(body[2:]))
+			rate = int(binary.LittleEndian.Uint32(body[4:]))
+		case "data":
+			pcm = body
+		}
+		// Chunks are padded to an even length
+		off += 8 + size + size%2
+	}
+	if channels == 0 || rate == 0 {
+		return nil, errors.New("missing fmt chunk")
+	}
+	track := resample(pcm, channels, rate)
+	if len(track) == 0 {
+		return nil, errors.New("no audio data")
+	}
+	return track, nil
+}
+
+// resample mixes 16-bit pcm down to mono at the device's rate, picking the
+// nearest frame for each sample
+func resample(pcm []byte, channels, rate int) []byte {
+	frames := len(pcm) / (2 * channels)
+	n := int(int64(frames) * sampleRate / int64(rate))
+	out := make([]byte, 2*n)
+	for i := range n {
+		frame := int(int64(i) * int64(rate) / sampleRate)
+		sum := 0
+		for c := range channels {
+			sum += int(int16(binary.LittleEndian.Uint16(pcm[2*(frame*channels+c):])))
+		}
+		binary.LittleEndian.PutUint16(out[2*i:], uint16(int16(sum/channels)))
+	}
+	return out
+}
+
+// tuneStep is how many samples each note of the built-in tune lasts:
+// 0.15 seconds
+const tuneStep = sampleRate * 3 / 20
+
+// rest is a step of the melody with no note
+const rest = math.MinInt
+
+// melody is the built-in tune's lead, one note per step in semitones from
+// A4. The bass follows bassRoots, one root per eight steps.
+var (
+	melody = []int{
+		0, 3, 7, 3, 12, 10, 7, 5,
+		3, 5, 7, rest, 7, 5, 3, -2,
+		0, 3, 7, 3, 12, 10, 7, 10,
+		12, rest, 10, 7, 5, 3, 0, rest,
+	}
+	bassRoots = []int{-24, -28, -21, -26}
+)
+
+// tune synthesizes the built-in loop: a square-wave lead over a triangle
+// bass that bounces between octaves
+func tune() []byte {
+	out := make([]byte, 2*tuneStep*len(melody))
+	for step, note := range melody {
+		bass := bassRoots[step/8%len(bassRoots)] + 12*(step%2)
+		for i := range tuneStep {
+			t := float64(step*tuneStep+i) / sampleRate
+			pluck := 1 - float64(i)/tuneStep
+			v := 0.25 * triangle(pitch(bass), t)
+			if note != rest {
+				v += 0.2 * pluck * square(pitch(note), t)
+			}
+			binary.LittleEndian.PutUint16(out[2*(step*tuneStep+i):], uint16(int16(v*math.MaxInt16)))
+		}
+	}
+	return out
+}
+
+// pitch is the frequency semitones away from A4
+func pitch(semitones int) float64 {
+	return 440 * math.Pow(2, float64(semitones)/12)
+}
+
+// square is a pulse wave with a 25% duty cycle, the classic chip lead
+func square(freq, t float64) float64 {
+	if math.Mod(freq*t, 1) < 0.25 {
+		return 1
+	}
+	return -1
+}
+
+// triangle is a triangle wave, the classic chip bass
+func triangle(freq, t float64) float64 {
+	return 4*math.Abs(math.Mod(freq*t, 1)-0.5) - 1
+}
@@ -0,0 +1,19 @@
+//go:build !audio
+
+package sound
+
+import "errors"
+
+// errNoAudio is returned when this build left the audio backend out to
+// avoid needing cgo and ALSA
+var errNoAudio = errors.New("built without audio support; rebuild with -tags audio")
+
+// NewAudio would play effects through the audio device
+func NewAudio() (Player, error) {
+	return nil, errNoAudio
+}
+
+// NewMusic would loop a track through the audio device
+func NewMusic(path string, volume float64) (Music, error) {
+	return nil, errNoAudio
+}
@@ -0,0 +1,81 @@
+// Package sound plays the game's sound effects, either by ringing the
+// terminal bell or through the audio device when built with the audio tag.
+package sound
+
+import (
+	"io"
+	"sort"
+)
+
+// Effect is a moment in the game that makes a sound
+type Effect int
+
+// Effects the game makes a sound for
+const (
+	Shoot Effect = iota
+	Pop
+	BossHit
+	GameOver
+)
+
+// names are the effects as written in the config file
+var names = map[string]Effect{
+	"shoot":     Shoot,
+	"pop":       Pop,
+	"boss_hit":  BossHit,
+	"game_over": GameOver,
+}
+
+// Lookup returns the effect with the given config name
+func Lookup(name string) (Effect, bool) {
+	e, ok := names[name]
+	return e, ok
+}
+
+// Names lists the effect names Lookup accepts, sorted
+func Names() []string {
+	list := make([]string, 0, len(names))
+	for name := range names {
+		list = append(list, name)
+	}
+	sort.Strings(list)
+	return list
+}
+
+// Player plays sound effects. The game calls Play off its Update
+// goroutine, but it should still return quickly.
+type Player interface {
+	Play(Effect)
+}
+
+// Music is a background track that loops while the game is being played.
+// Its methods only pass a request on to the track's own goroutine, so they
+// never wait on the audio device.
+type Music interface {
+	Resume()
+	Pause()
+	SetVolume(volume float64) // 0 is silent, 1 is full
+}
+
+// Bell rings the terminal bell for some effects and ignores the rest
+type Bell struct {
+	w  io.Writer
+	on map[Effect]bool
+}
+
+// NewBell rings the bell on w for each of effects
+func NewBell(w io.Writer, effects ...Effect) Bell {
+	on := make(map[Effect]bool, len(effects))
+	for _, e := range effects {
+		on[e] = true
+	}
+	return Bell{w: w, on: on}
+}
+
+// Play rings the bell if e is one of the bell's effects
+func (b Bell) Play(e Effect) {
+	if b.on[e] {
+		// There's nowhere to report a failed bell, and no need to
+		b.w.Write([]byte("\a"))
+	}
+}
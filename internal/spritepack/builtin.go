@@ -0,0 +1,56 @@
+package spritepack
+
+import "github.com/ashX04/gobowarrow/internal/assets"
+
+// Names of the packs shipped with the game
+const (
+	Emoji    = "emoji"    // emoji balloons; needs a color emoji font
+	NerdFont = "nerdfont" // Nerd Font icons; needs a patched font
+)
+
+// builtin are the packs shipped with the game, by name
+var builtin = map[string]Pack{
+	Emoji: {
+		Balloons: []assets.Sprite{
+			sprite(0, "bunch", cluster("🎈")),
+			sprite(1, "target", cluster("🎯")),
+			sprite(2, "gift", cluster("🎁")),
+			sprite(3, "apple", cluster("🍏")),
+		},
+		Archer: "🏹",
+		Arrow:  "─➳",
+	},
+	NerdFont: {
+		Balloons: []assets.Sprite{
+			sprite(0, "heart", outline("\uf004")),    // nf-fa-heart
+			sprite(1, "bullseye", outline("\uf140")), // nf-fa-bullseye
+			sprite(2, "gift", outline("\uf06b")),     // nf-fa-gift
+			sprite(3, "star", outline("\uf005")),     // nf-fa-star
+		},
+		Archer: "\U000f1841", // nf-md-bow_arrow
+		Arrow:  "─\uf178",    // nf-fa-long_arrow_right
+	},
+}
+
+// cluster bunches an emoji into balloon art on a string. Emoji are two
+// columns wide, so the rows line up by columns rather than runes.
+func cluster(emoji string) []string {
+	return []string{
+		" " + emoji + emoji + " ",
+		emoji + emoji + emoji,
+		" " + emoji + emoji + " ",
+		"  ||  ",
+	}
+}
+
+// outline puts a one-column icon in the middle of a balloon outline
+func outline(icon string) []string {
+	return []string{
+		"  .===.",
+		" /     \\",
+		"|   " + icon + "   |",
+		" \\     /",
+		"  `---´",
+		"   ||   ",
+	}
+}
@@ -0,0 +1,192 @@
+// Package spritepack provides sprite packs: sets of balloons, and the bow
+// and arrow to shoot them with, that replace the built-in art. A few packs
+// ship with the game; the rest are folders on disk.
+//
+// A folder pack is a directory of text files, one balloon per .txt file
+// and named after it. An optional pack.toml gives each balloon a color and the
+// markers accessible mode draws on it:
+//
+//	[heart]
+//	color = "196"
+//	marker = "♥"
+//	letter = "h"
+package spritepack
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/BurntSushi/toml"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/rivo/uniseg"
+
+	"github.com/ashX04/gobowarrow/internal/assets"
+)
+
+// Limits on a pack's art so every balloon fits on the board
+const (
+	MaxWidth  = 16
+	MaxHeight = 8
+)
+
+// metaFile is the optional file holding a pack's colors and markers
+const metaFile = "pack.toml"
+
+// Pack is a set of balloons and how the archer looks shooting them
+type Pack struct {
+	Balloons []assets.Sprite
+	Archer   string // bow glyph; empty keeps the built-in bow
+	Arrow    string // standard arrow in flight; empty keeps the built-in one
+}
+
+// meta is one balloon's entry in pack.toml
+type meta struct {
+	Color  lipgloss.Color `toml:"color"`
+	Marker string         `toml:"marker"`
+	Letter string         `toml:"letter"`
+}
+
+// DefaultDir returns where packs are kept, under the user config dir
+func DefaultDir() (string, error) {
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "bowarrow", "sprites"), nil
+}
+
+// Names lists the packs to pick from: "" for the built-in balloons, the
+// packs shipped with the game, then the folders in the sprites dir
+func Names() []string {
+	names := []string{""}
+	for name := range builtin {
+		names = append(names, name)
+	}
+	sort.Strings(names[1:])
+	dir, err := DefaultDir()
+	if err != nil {
+		return names
+	}
+	entries, _ := os.ReadDir(dir)
+	for _, e := range entries {
+		if _, shipped := builtin[e.Name()]; e.IsDir() && !shipped && !strings.HasPrefix(e.Name(), ".") {
+			names = append(names, e.Name())
+		}
+	}
+	return names
+}
+
+// Open returns the pack called name: one shipped with the game, or else
+// the folder of that name in the sprites dir
+func Open(name string) (Pack, error) {
+	if p, ok := builtin[name]; ok {
+		return p, nil
+	}
+	dir, err := DefaultDir()
+	if err != nil {
+		return Pack{}, err
+	}
+	return Load(filepath.Join(dir, name))
+}
+
+// Load reads the folder pack in dir, sorted by name. Balloons pack.toml
+// says nothing about take their color and markers from the built-in
+// balloons in turn.
+func Load(dir string) (Pack, error) {
+	paths, err := filepath.Glob(filepath.Join(dir, "*.txt"))
+	if err != nil {
+		return Pack{}, err
+	}
+	if len(paths) == 0 {
+		if _, err := os.Stat(dir); err != nil {
+			return Pack{}, err
+		}
+		return Pack{}, fmt.Errorf("%s: no .txt sprites", dir)
+	}
+	sort.Strings(paths)
+
+	metas := map[string]meta{}
+	if _, err := toml.DecodeFile(filepath.Join(dir, metaFile), &metas); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return Pack{}, fmt.Errorf("%s: %w", metaFile, err)
+	}
+
+	pack := make([]assets.Sprite, 0, len(paths))
+	for i, path := range paths {
+		name := strings.TrimSuffix(filepath.Base(path), ".txt")
+		art, err := readArt(path)
+		if err != nil {
+			return Pack{}, fmt.Errorf("%s: %w", filepath.Base(path), err)
+		}
+		s := sprite(i, name, art)
+		if m, ok := metas[name]; ok {
+			if m.Color != "" {
+				s.Color = m.Color
+			}
+			if m.Marker != "" {
+				s.Marker = m.Marker
+			}
+			if m.Letter != "" {
+				s.Letter = m.Letter
+			}
+			delete(metas, name)
+		}
+		pack = append(pack, s)
+	}
+	if len(metas) > 0 {
+		unknown := make([]string, 0, len(metas))
+		for name := range metas {
+			unknown = append(unknown, name)
+		}
+		sort.Strings(unknown)
+		return Pack{}, fmt.Errorf("%s: no sprite named %q", metaFile, unknown[0])
+	}
+	return Pack{Balloons: pack}, nil
+}
+
+// readArt reads one balloon's art, dropping blank lines at either end
+func readArt(path string) ([]string, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	lines := strings.Split(strings.ReplaceAll(string(data), "\r\n", "\n"), "\n")
+	for len(lines) > 0 && strings.TrimSpace(lines[len(lines)-1]) == "" {
+		lines = lines[:len(lines)-1]
+	}
+	for len(lines) > 0 && strings.TrimSpace(lines[0]) == "" {
+		lines = lines[1:]
+	}
+
+	switch {
+	case len(lines) == 0:
+		return nil, errors.New("art is empty")
+	case len(lines) > MaxHeight:
+		return nil, fmt.Errorf("art is taller than %d rows", MaxHeight)
+	}
+	for _, line := range lines {
+		if strings.ContainsRune(line, '\t') {
+			return nil, errors.New("art must use spaces, not tabs")
+		}
+		if uniseg.StringWidth(line) > MaxWidth {
+			return nil, fmt.Errorf("art is wider than %d columns", MaxWidth)
+		}
+	}
+	return lines, nil
+}
+
+// sprite makes the i-th balloon of a pack, colored and marked like the
+// built-in balloon in the same place
+func sprite(i int, name string, art []string) assets.Sprite {
+	like := assets.Balloons[i%len(assets.Balloons)]
+	return assets.Sprite{
+		Name:   name,
+		Art:    art,
+		Color:  like.Color,
+		Marker: like.Marker,
+		Letter: like.Letter,
+	}
+}
@@ -0,0 +1,106 @@
+// Package sshserve hosts the game over SSH. Every session gets a game of
+// its own, and all of them are ranked on one leaderboard kept by the
+// server, with players told apart by their public keys.
+package sshserve
+
+import (
+	"context"
+	"errors"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"syscall"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/ssh"
+	"github.com/charmbracelet/wish"
+	"github.com/charmbracelet/wish/activeterm"
+	bm "github.com/charmbracelet/wish/bubbletea"
+	"github.com/charmbracelet/wish/logging"
+	"github.com/muesli/termenv"
+	gossh "golang.org/x/crypto/ssh"
+
+	"github.com/ashX04/gobowarrow/internal/config"
+	"github.com/ashX04/gobowarrow/internal/game"
+	"github.com/ashX04/gobowarrow/internal/leaderboard"
+)
+
+// shutdownTimeout is how long sessions get to finish once the server stops
+const shutdownTimeout = 10 * time.Second
+
+// HostKeyPath returns where the server's host key is kept. It's generated
+// on first use.
+func HostKeyPath() (string, error) {
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "bowarrow", "ssh_host_ed25519"), nil
+}
+
+// Serve hosts the game on addr until the process is interrupted
+func Serve(addr, hostKeyPath string, cfg config.Config, board *leaderboard.Local) error {
+	// Styles are rendered through lipgloss's shared renderer, which would
+	// otherwise match the server's terminal rather than the players'
+	profile := termenv.ANSI256
+	if cfg.Monochrome {
+		profile = termenv.Ascii
+	}
+	lipgloss.SetColorProfile(profile)
+	// For the same reason the background can't be asked for, so players
+	// get the dark colors unless the config says otherwise
+	lipgloss.SetHasDarkBackground(cfg.Background != config.BackgroundLight)
+
+	s, err := wish.NewServer(
+		wish.WithAddress(addr),
+		wish.WithHostKeyPath(hostKeyPath),
+		// Any key is welcome; it only identifies the player on the leaderboard
+		wish.WithPublicKeyAuth(func(ssh.Context, ssh.PublicKey) bool { return true }),
+		wish.WithMiddleware(
+			bm.Middleware(handler(cfg, board)),
+			activeterm.Middleware(),
+			logging.Middleware(),
+		),
+	)
+	if err != nil {
+		return err
+	}
+
+	stop := make(chan os.Signal, 1)
+	signal.Notify(stop, os.Interrupt, syscall.SIGTERM)
+	errc := make(chan error, 1)
+	go func() { errc <- s.ListenAndServe() }()
+
+	select {
+	case err := <-errc:
+		if !errors.Is(err, ssh.ErrServerClosed) {
+			return err
+		}
+		return nil
+	case <-stop:
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+	defer cancel()
+	return s.Shutdown(ctx)
+}
+
+// handler starts a game for each session, named after the SSH user
+func handler(cfg config.Config, board *leaderboard.Local) bm.Handler {
+	return func(s ssh.Session) (tea.Model, []tea.ProgramOption) {
+		c := cfg
+		c.PlayerName = playerName(s.User())
+		key := gossh.FingerprintSHA256(s.PublicKey())
+		return game.New(c).WithLeaderboard(board.For(key)).WithoutSuspend(), []tea.ProgramOption{tea.WithAltScreen()}
+	}
+}
+
+// playerName fits an SSH user name to the leaderboard's name limit
+func playerName(user string) string {
+	name := []rune(user)
+	if len(name) > config.MaxPlayerName {
+		name = name[:config.MaxPlayerName]
+	}
+	return string(name)
+}
@@ -0,0 +1,128 @@
+package theme
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// TimeOfDay is how far through a day the sky is: 0 is dawn, a quarter is
+// midday, a half is dusk and three quarters is midnight. It wraps at 1.
+type TimeOfDay float64
+
+// skyTints are the colors the sky leans toward at dawn, midday, dusk and
+// midnight, a quarter of a day apart. Midday leaves a theme as it is.
+var skyTints = [4]Color{
+	both("#ff875f"),
+	{},
+	both("#d75f87"),
+	pair("#5f5faf", "#303087"),
+}
+
+// Tint shades c toward the sky's color at d. Strength is how far it can go,
+// from 0 for not at all to 1 for the sky's color itself; it moves smoothly
+// between the tints either side of d.
+func (d TimeOfDay) Tint(c Color, strength float64) Color {
+	at := func(i int) Color {
+		if skyTints[i] == (Color{}) {
+			return c
+		}
+		return Blend(c, skyTints[i], strength)
+	}
+	pos := d.wrapped() * float64(len(skyTints))
+	i := int(pos)
+	return Blend(at(i), at((i+1)%len(skyTints)), pos-float64(i))
+}
+
+// Darkness is how deep into the night d is, from 0 at dusk and dawn to 1
+// at midnight
+func (d TimeOfDay) Darkness() float64 {
+	return max(0, 1-4*math.Abs(d.wrapped()-0.75))
+}
+
+// wrapped is d as a fraction of a day from 0 up to 1
+func (d TimeOfDay) wrapped() float64 {
+	f := math.Mod(float64(d), 1)
+	if f < 0 {
+		f++
+	}
+	return f
+}
+
+// Blend mixes a into b, from all a at 0 to all b at 1, shade by shade. The
+// result is a hex color; terminals without true color show the nearest
+// color they have.
+func Blend(a, b Color, t float64) Color {
+	switch {
+	case t <= 0:
+		return a
+	case t >= 1:
+		return b
+	}
+	return pair(mix(a.Light, b.Light, t), mix(a.Dark, b.Dark, t))
+}
+
+// Hex is c's shade for the terminal's background as a hex color, for the
+// components that only take those
+func (c Color) Hex() string {
+	shade := c.Light
+	if lipgloss.HasDarkBackground() {
+		shade = c.Dark
+	}
+	r, g, b := rgb(shade)
+	return fmt.Sprintf("#%02x%02x%02x", r, g, b)
+}
+
+// mix blends two colors given as config strings
+func mix(a, b string, t float64) string {
+	ra, ga, ba := rgb(a)
+	rb, gb, bb := rgb(b)
+	lerp := func(x, y uint8) uint8 {
+		return uint8(math.Round(float64(x) + (float64(y)-float64(x))*t))
+	}
+	return fmt.Sprintf("#%02x%02x%02x", lerp(ra, rb), lerp(ga, gb), lerp(ba, bb))
+}
+
+// ansi16 are the usual values of the 16 basic terminal colors
+var ansi16 = [16][3]uint8{
+	{0, 0, 0}, {128, 0, 0}, {0, 128, 0}, {128, 128, 0},
+	{0, 0, 128}, {128, 0, 128}, {0, 128, 128}, {192, 192, 192},
+	{128, 128, 128}, {255, 0, 0}, {0, 255, 0}, {255, 255, 0},
+	{0, 0, 255}, {255, 0, 255}, {0, 255, 255}, {255, 255, 255},
+}
+
+// rgb is the red, green and blue of a color Validate accepts: a hex color
+// or a 256-color code. Anything else, such as an empty color, is black.
+func rgb(c string) (r, g, b uint8) {
+	if hexColor.MatchString(c) {
+		hex := c[1:]
+		if len(hex) == 3 {
+			hex = string([]byte{hex[0], hex[0], hex[1], hex[1], hex[2], hex[2]})
+		}
+		v, _ := strconv.ParseUint(hex, 16, 32)
+		return uint8(v >> 16), uint8(v >> 8), uint8(v)
+	}
+	n, err := strconv.Atoi(c)
+	switch {
+	case err != nil || n < 0 || n > 255:
+		return 0, 0, 0
+	case n < 16:
+		return ansi16[n][0], ansi16[n][1], ansi16[n][2]
+	case n < 232:
+		// The 6x6x6 color cube
+		level := func(i int) uint8 {
+			if i == 0 {
+				return 0
+			}
+			return uint8(55 + 40*i)
+		}
+		n -= 16
+		return level(n / 36), level(n / 6 % 6), level(n % 6)
+	default:
+		// The grayscale ramp
+		v := uint8(8 + 10*(n-232))
+		return v, v, v
+	}
+}
@@ -0,0 +1,321 @@
+// Package theme defines the color schemes the game can be drawn in.
+package theme
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"strconv"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// Color is a color with one shade for light terminals and one for dark
+// ones. In the config file it's a single color ("213" or "#d33682") used
+// on both, or a table with a light and a dark color.
+type Color struct {
+	lipgloss.AdaptiveColor
+}
+
+// pair is a color with different shades for light and dark backgrounds
+func pair(light, dark string) Color {
+	return Color{lipgloss.AdaptiveColor{Light: light, Dark: dark}}
+}
+
+// both is a color that reads well on either background
+func both(c string) Color {
+	return pair(c, c)
+}
+
+// UnmarshalTOML reads a color or a {light, dark} table
+func (c *Color) UnmarshalTOML(v any) error {
+	switch v := v.(type) {
+	case string:
+		*c = both(v)
+	case map[string]any:
+		light, _ := v["light"].(string)
+		dark, _ := v["dark"].(string)
+		for key := range v {
+			if key != "light" && key != "dark" {
+				return fmt.Errorf("unknown key %q in color; want light and dark", key)
+			}
+		}
+		// One shade alone is used on both backgrounds
+		if light == "" {
+			light = dark
+		}
+		if dark == "" {
+			dark = light
+		}
+		*c = pair(light, dark)
+	default:
+		return fmt.Errorf("want a color or a {light, dark} table, got %v", v)
+	}
+	return nil
+}
+
+// MarshalTOML writes c back in the shortest form that reads the same
+func (c Color) MarshalTOML() ([]byte, error) {
+	if c.Light == c.Dark {
+		return []byte(strconv.Quote(c.Dark)), nil
+	}
+	return []byte(fmt.Sprintf("{ light = %s, dark = %s }", strconv.Quote(c.Light), strconv.Quote(c.Dark))), nil
+}
+
+// Theme gives each part of the screen its color
+type Theme struct {
+	Name     string `toml:"-"`
+	Base     string `toml:"base,omitempty"`     // built-in theme a custom one starts from
+	Title    Color  `toml:"title,omitempty"`    // titles and headers
+	Text     Color  `toml:"text,omitempty"`     // menu items and table rows
+	Accent   Color  `toml:"accent,omitempty"`   // the archer, selected items and timers
+	Score    Color  `toml:"score,omitempty"`    // the score line and counters
+	Muted    Color  `toml:"muted,omitempty"`    // hints and controls
+	Faint    Color  `toml:"faint,omitempty"`    // empty slots, the aim guide and the paused board
+	Border   Color  `toml:"border,omitempty"`   // board and screen borders
+	Danger   Color  `toml:"danger,omitempty"`   // lives, warnings, errors and the boss
+	Success  Color  `toml:"success,omitempty"`  // a completed level
+	Contrast Color  `toml:"contrast,omitempty"` // text on a colored background
+	Guest    Color  `toml:"guest,omitempty"`    // the second archer in a networked match
+	Chat     Color  `toml:"chat,omitempty"`     // the viewers' shout-out feed
+
+	// Balloons recolors balloon sprites by name; others keep their own
+	// color. Balloons are bright enough to use one color on any background.
+	Balloons map[string]lipgloss.Color `toml:"balloons,omitempty"`
+}
+
+var themes = []Theme{
+	{
+		Name:     "classic",
+		Title:    pair("163", "213"),
+		Text:     pair("236", "252"),
+		Accent:   pair("166", "214"),
+		Score:    pair("161", "205"),
+		Muted:    pair("240", "241"),
+		Faint:    pair("248", "240"),
+		Border:   pair("25", "63"),
+		Danger:   pair("160", "204"),
+		Success:  pair("28", "48"),
+		Contrast: pair("231", "230"),
+		Guest:    pair("31", "45"),
+		Chat:     pair("91", "141"),
+	},
+	{
+		Name:     "neon",
+		Title:    pair("163", "201"),
+		Text:     pair("235", "231"),
+		Accent:   pair("172", "226"),
+		Score:    pair("31", "51"),
+		Muted:    pair("242", "245"),
+		Faint:    pair("249", "239"),
+		Border:   pair("163", "201"),
+		Danger:   pair("161", "197"),
+		Success:  pair("28", "46"),
+		Contrast: both("231"),
+		Guest:    pair("30", "87"),
+		Chat:     pair("127", "213"),
+		Balloons: map[string]lipgloss.Color{"round": "201", "oval": "197", "ring": "51", "dot": "46"},
+	},
+	{
+		Name:     "pastel",
+		Title:    pair("168", "218"),
+		Text:     pair("238", "254"),
+		Accent:   pair("173", "223"),
+		Score:    pair("97", "183"),
+		Muted:    pair("244", "247"),
+		Faint:    pair("250", "243"),
+		Border:   pair("110", "153"),
+		Danger:   pair("167", "210"),
+		Success:  pair("71", "157"),
+		Contrast: pair("231", "236"),
+		Guest:    pair("73", "159"),
+		Chat:     pair("103", "189"),
+		Balloons: map[string]lipgloss.Color{"round": "218", "oval": "217", "ring": "153", "dot": "157"},
+	},
+	{
+		// Solarized's accents are meant for both its light and dark modes;
+		// only the base tones swap
+		Name:     "solarized",
+		Title:    both("#d33682"),
+		Text:     pair("#586e75", "#93a1a1"),
+		Accent:   both("#b58900"),
+		Score:    both("#cb4b16"),
+		Muted:    pair("#657b83", "#839496"),
+		Faint:    pair("#93a1a1", "#586e75"),
+		Border:   both("#268bd2"),
+		Danger:   both("#dc322f"),
+		Success:  both("#859900"),
+		Contrast: both("#fdf6e3"),
+		Guest:    both("#2aa198"),
+		Chat:     both("#6c71c4"),
+		Balloons: map[string]lipgloss.Color{"round": "#d33682", "oval": "#dc322f", "ring": "#268bd2", "dot": "#859900"},
+	},
+	{
+		// Black and white text with strong accents. Balloons use the
+		// Okabe-Ito colors, which stay distinct under common color blindness.
+		Name:     HighContrast,
+		Title:    pair("18", "226"),
+		Text:     pair("16", "231"),
+		Accent:   pair("166", "220"),
+		Score:    pair("16", "231"),
+		Muted:    pair("235", "252"),
+		Faint:    pair("242", "247"),
+		Border:   pair("16", "231"),
+		Danger:   pair("160", "196"),
+		Success:  pair("22", "46"),
+		Contrast: pair("231", "16"),
+		Guest:    pair("25", "51"),
+		Chat:     pair("90", "219"),
+		Balloons: map[string]lipgloss.Color{"round": "#e69f00", "oval": "#d55e00", "ring": "#56b4e9", "dot": "#009e73"},
+	},
+	{
+		// Soft, low colors for Zen mode, with nothing to alarm: even
+		// danger is a dusky rose
+		Name:     Zen,
+		Title:    pair("66", "109"),
+		Text:     pair("239", "250"),
+		Accent:   pair("101", "144"),
+		Score:    pair("66", "108"),
+		Muted:    pair("245", "243"),
+		Faint:    pair("252", "238"),
+		Border:   pair("109", "66"),
+		Danger:   pair("132", "174"),
+		Success:  pair("65", "108"),
+		Contrast: pair("231", "235"),
+		Guest:    pair("60", "146"),
+		Chat:     pair("96", "182"),
+		Balloons: map[string]lipgloss.Color{"round": "174", "oval": "180", "ring": "110", "dot": "108"},
+	},
+}
+
+// Default is the theme used when none is chosen
+const Default = "classic"
+
+// Zen is the built-in theme Zen mode is drawn in by default
+const Zen = "zen"
+
+// HighContrast is the built-in theme for low vision and color blindness
+const HighContrast = "high-contrast"
+
+// Lookup returns the named theme: one of custom, or failing that a built-in
+// theme. A custom theme only needs the colors it changes; the rest come from
+// its base, or the default theme if it has none.
+func Lookup(name string, custom map[string]Theme) (Theme, bool) {
+	if t, ok := custom[name]; ok {
+		base, ok := builtin(t.Base)
+		if t.Base == "" {
+			base, ok = builtin(Default)
+		}
+		if !ok {
+			return Theme{}, false
+		}
+		t.Name = name
+		return t.over(base), true
+	}
+	return builtin(name)
+}
+
+// builtin returns the built-in theme with the given name
+func builtin(name string) (Theme, bool) {
+	for _, t := range themes {
+		if t.Name == name {
+			return t, true
+		}
+	}
+	return Theme{}, false
+}
+
+// Names lists the built-in themes followed by the custom ones, sorted
+func Names(custom map[string]Theme) []string {
+	var names []string
+	for _, t := range themes {
+		names = append(names, t.Name)
+	}
+	var extra []string
+	for name := range custom {
+		if !slices.Contains(names, name) {
+			extra = append(extra, name)
+		}
+	}
+	slices.Sort(extra)
+	return append(names, extra...)
+}
+
+// Balloon is the color to draw the named balloon sprite in, given the
+// sprite's own color
+func (t Theme) Balloon(sprite string, own lipgloss.Color) lipgloss.Color {
+	if c, ok := t.Balloons[sprite]; ok {
+		return c
+	}
+	return own
+}
+
+// color is one of a theme's colors and its name in the config file
+type color struct {
+	name  string
+	color *Color
+}
+
+// colors lists every color t sets for a part of the screen
+func (t *Theme) colors() []color {
+	return []color{
+		{"title", &t.Title}, {"text", &t.Text}, {"accent", &t.Accent}, {"score", &t.Score},
+		{"muted", &t.Muted}, {"faint", &t.Faint}, {"border", &t.Border}, {"danger", &t.Danger},
+		{"success", &t.Success}, {"contrast", &t.Contrast}, {"guest", &t.Guest}, {"chat", &t.Chat},
+	}
+}
+
+// over fills the colors t leaves unset from base
+func (t Theme) over(base Theme) Theme {
+	from := base.colors()
+	for i, c := range t.colors() {
+		if *c.color == (Color{}) {
+			*c.color = *from[i].color
+		}
+	}
+	balloons := make(map[string]lipgloss.Color, len(base.Balloons)+len(t.Balloons))
+	for name, c := range base.Balloons {
+		balloons[name] = c
+	}
+	for name, c := range t.Balloons {
+		balloons[name] = c
+	}
+	t.Balloons = balloons
+	return t
+}
+
+// hexColor matches "#rgb" and "#rrggbb"
+var hexColor = regexp.MustCompile(`^#([0-9a-fA-F]{3}|[0-9a-fA-F]{6})$`)
+
+// Validate rejects a custom theme whose base or colors can't be used
+func (t Theme) Validate() error {
+	if t.Base != "" {
+		if _, ok := builtin(t.Base); !ok {
+			return fmt.Errorf("base must be a built-in theme, got %q", t.Base)
+		}
+	}
+	for _, c := range t.colors() {
+		for _, shade := range []string{c.color.Light, c.color.Dark} {
+			if err := check(lipgloss.Color(shade)); err != nil {
+				return fmt.Errorf("%s: %w", c.name, err)
+			}
+		}
+	}
+	for name, c := range t.Balloons {
+		if err := check(c); err != nil {
+			return fmt.Errorf("balloons.%s: %w", name, err)
+		}
+	}
+	return nil
+}
+
+// check accepts an empty color, a 256-color code or a hex color
+func check(c lipgloss.Color) error {
+	if c == "" || hexColor.MatchString(string(c)) {
+		return nil
+	}
+	if n, err := strconv.Atoi(string(c)); err == nil && n >= 0 && n <= 255 {
+		return nil
+	}
+	return fmt.Errorf("want a color code from 0 to 255 or #rrggbb, got %q", c)
+}
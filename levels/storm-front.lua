@@ -0,0 +1,42 @@
+-- Storm Front: a gust brings more balloons every 20 seconds and a boss
+-- rolls in halfway. Beat the boss and reach 60 points to clear it.
+--
+-- Scripts can call:
+--   at(seconds, fn)         run fn once, that many seconds in
+--   every(seconds, fn)      run fn every that many seconds
+--   on_tick()               if defined, runs every tick
+--   game.spawn(kind, n)     "balloon" (one of the level's), "golden" or a sprite name
+--   game.boss()             bring on the boss, if there isn't one
+--   game.boss_hp()          hits the boss has left; 0 when there's none
+--   game.effect(name)       rapid_fire, triple_shot, slow_motion, double_points or time_freeze
+--   game.banner(text)       show a banner; the level's own spawns hold while it's up
+--   game.score(), game.add_score(n), game.escaped()
+--   game.random(n)          1..n from the run's seed
+--   game.time()             seconds into the run
+--   game.win(), game.lose()
+
+local gusts = 0
+local boss_came = false
+
+every(20, function()
+  gusts = gusts + 1
+  game.banner("Gust " .. gusts .. "!")
+  game.spawn("balloon", 2 + gusts)
+end)
+
+at(45, function()
+  game.banner("Here it comes")
+  game.boss()
+  boss_came = true
+end)
+
+at(60, function()
+  game.effect("double_points")
+  game.spawn("golden")
+end)
+
+function on_tick()
+  if boss_came and game.boss_hp() == 0 and game.score() >= 60 then
+    game.win()
+  end
+end
@@ -1,360 +1,513 @@
 package main
 
 import (
+	"encoding/json"
+	"errors"
+	"flag"
 	"fmt"
-	"math/rand"
+	"os"
+	"os/signal"
+	"runtime/debug"
+	"strings"
+	"syscall"
 	"time"
 
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
+	"github.com/muesli/termenv"
+
+	"github.com/ashX04/gobowarrow/extension"
+	"github.com/ashX04/gobowarrow/internal/achievements"
+	"github.com/ashX04/gobowarrow/internal/chat"
+	"github.com/ashX04/gobowarrow/internal/config"
+	"github.com/ashX04/gobowarrow/internal/daily"
+	"github.com/ashX04/gobowarrow/internal/difficulty"
+	"github.com/ashX04/gobowarrow/internal/game"
+	"github.com/ashX04/gobowarrow/internal/leaderboard"
+	"github.com/ashX04/gobowarrow/internal/level"
+	"github.com/ashX04/gobowarrow/internal/netplay"
+	"github.com/ashX04/gobowarrow/internal/plugins"
+	"github.com/ashX04/gobowarrow/internal/profile"
+	"github.com/ashX04/gobowarrow/internal/replay"
+	"github.com/ashX04/gobowarrow/internal/savegame"
+	"github.com/ashX04/gobowarrow/internal/scores"
+	"github.com/ashX04/gobowarrow/internal/sound"
+	"github.com/ashX04/gobowarrow/internal/spritepack"
+	"github.com/ashX04/gobowarrow/internal/sshserve"
+	"github.com/ashX04/gobowarrow/internal/theme"
 )
 
-// Game states
-const (
-	playing = iota
-	gameOver
-)
-
-// Balloon represents a target
-type Balloon struct {
-	x, y   int
-	popped bool
-	symbol []string // Changed to string slice for multi-line art
-	color  lipgloss.Color
-	width  int
-	height int
+func main() {
+	os.Exit(run())
 }
 
-// Arrow represents the player's projectile
-type Arrow struct {
-	x, y   int
-	active bool
-	symbol string
-}
+// run is the whole program, returning its exit code. Everything deferred
+// in it, profiling above all, is done before the process exits, however
+// it ends.
+func run() int {
+	difficultyName := flag.String("difficulty", "", "difficulty: "+strings.Join(difficulty.Names(), ", "))
+	width := flag.Int("width", 0, "board width in columns")
+	height := flag.Int("height", 0, "board height in rows")
+	seed := flag.Int64("seed", 0, "RNG seed for a reproducible game (0 = random)")
+	levelPath := flag.String("level", "", "path to a level file to play")
+	rendererName := flag.String("renderer", "", "arrow renderer: "+strings.Join(config.Renderers, ", "))
+	headless := flag.Bool("headless", false, "simulate without a terminal and print the run's stats as JSON")
+	ticks := flag.Int("ticks", 1000, "ticks to simulate in headless mode")
+	versus := flag.Bool("versus", false, "two players race side by side on one keyboard")
+	vsBot := flag.Bool("bot", false, "race the computer side by side")
+	accessible := flag.Bool("accessible", false, "mark balloon types with symbols and use high-contrast colors")
+	reducedMotion := flag.Bool("reduced-motion", false, "turn off screen shake, flashing and flicker")
+	ascii := flag.Bool("ascii", false, "draw with plain ASCII for terminals whose fonts lack the game's symbols")
+	pluginPaths := flag.String("plugins", "", "comma-separated Go plugins adding their own entities to the waves")
+	spectate := flag.String("spectate", "", "let others watch on this TCP address or unix socket path")
+	var prof profiling
+	flag.StringVar(&prof.cpu, "cpuprofile", "", "write a CPU profile to this file")
+	flag.StringVar(&prof.mem, "memprofile", "", "write a heap profile to this file on exit")
+	flag.StringVar(&prof.trace, "trace", "", "write an execution trace to this file")
+	flag.StringVar(&prof.listen, "pprof", "", "serve live profiles over HTTP on this address, e.g. localhost:6060")
+	flag.Usage = func() {
+		fmt.Fprintf(flag.CommandLine.Output(), "Usage:\n"+
+			"  %[1]s [flags]             play\n"+
+			"  %[1]s [flags] host [addr] host a networked match (default %[2]s)\n"+
+			"  %[1]s join addr           join a networked match\n"+
+			"  %[1]s watch addr          watch a game played with --spectate\n"+
+			"  %[1]s [flags] serve [--ssh addr] serve the game over SSH (default %[3]s)\n\nFlags:\n",
+			os.Args[0], defaultAddr, defaultSSHAddr)
+		flag.PrintDefaults()
+	}
+	flag.Parse()
 
-// Model represents the game state
-type Model struct {
-	width, height int
-	archer        int // archer's vertical position
-	arrows        []Arrow
-	balloons      []Balloon
-	score         int
-	state         int
-	timer         int
-	minBalloonX   int // Add this field
-	maxBalloonX   int // Add this field
-}
+	stopProfiling, err := prof.start()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Could not start profiling: %v\n", err)
+		return 1
+	}
+	defer stopProfiling()
+
+	// Joining and watching only show someone else's game, so they need no
+	// local settings
+	if flag.Arg(0) == "join" || flag.Arg(0) == "watch" {
+		if flag.NArg() != 2 {
+			flag.Usage()
+			return 2
+		}
+		return join(flag.Arg(1), flag.Arg(0) == "join")
+	}
 
-// Initialize the game
-func initialModel() Model {
-	width := 80
-	return Model{
-		width:       width - 2, // Account for padding
-		height:      20,
-		archer:      10,
-		arrows:      make([]Arrow, 0),
-		balloons:    make([]Balloon, 0),
-		state:       playing,
-		timer:       0,
-		minBalloonX: (width - 2) / 2, // Account for padding
-		maxBalloonX: width - 7,       // Account for padding and balloon width
+	cfg, configPath := config.Default(), ""
+	if path, err := config.DefaultPath(); err == nil {
+		loaded, err := config.Load(path)
+		if err != nil {
+			fmt.Printf("Could not load config, using defaults: %v\n", err)
+		} else {
+			// Settings are only saved back over a file that loaded cleanly
+			configPath = path
+		}
+		cfg = loaded
 	}
-}
+	configFile := cfg
 
-func (m Model) Init() tea.Cmd {
-	return tea.Batch(tick(), spawnBalloon())
-}
+	// Flags override the config file
+	if *difficultyName != "" {
+		cfg.Difficulty = *difficultyName
+	}
+	if *width != 0 {
+		cfg.Width = *width
+	}
+	if *height != 0 {
+		cfg.Height = *height
+	}
+	if *seed != 0 {
+		cfg.Seed = *seed
+	}
+	if *rendererName != "" {
+		cfg.Renderer = *rendererName
+	}
+	if *accessible {
+		cfg.Accessible = true
+		cfg.Theme = theme.HighContrast
+	}
+	if *reducedMotion {
+		cfg.ReducedMotion = true
+	}
+	if *ascii {
+		cfg.ASCII = true
+	}
+	if err := cfg.Validate(); err != nil {
+		fmt.Fprintf(os.Stderr, "Invalid options: %v\n", err)
+		return 2
+	}
 
-// Update handles game logic
-func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
-	switch msg := msg.(type) {
-	case tea.KeyMsg:
-		switch msg.String() {
-		case "q", "ctrl+c":
-			return m, tea.Quit
-		case "up":
-			if m.archer > 0 {
-				m.archer--
-			}
-		case "down":
-			if m.archer < m.height-1 {
-				m.archer++
-			}
-		case " ": // Space to shoot
-			if len(m.arrows) < 3 { // Limit arrows
-				m.arrows = append(m.arrows, Arrow{
-					x:      2,
-					y:      m.archer,
-					active: true,
-					symbol: "═>", // Longer arrow symbol
-				})
-			}
+	// The SSH server picks colors for its players, not this terminal
+	if flag.Arg(0) != "serve" {
+		setBackground(cfg.Background)
+		if monochrome() {
+			cfg.Monochrome = true
+		}
+		if cfg.Monochrome {
+			lipgloss.SetColorProfile(termenv.Ascii)
 		}
+		if asciiOnly() {
+			cfg.ASCII = true
+		}
+	}
 
-	case spawnMsg:
-		balloon := Balloon(msg)
-		m.balloons = append(m.balloons, balloon)
-		return m, nil
-
-	case tickMsg:
-		// Update arrows
-		for i := range m.arrows {
-			if m.arrows[i].active {
-				m.arrows[i].x += 2
-				if m.arrows[i].x >= m.width {
-					m.arrows[i].active = false
-				}
-			}
+	// Versus is its own program; scores and levels don't apply to it
+	if *versus {
+		return play(game.NewVersus(cfg), *spectate)
+	}
+	if *vsBot {
+		return play(game.NewBotMatch(cfg), *spectate)
+	}
+
+	switch flag.Arg(0) {
+	case "":
+	case "host":
+		addr := defaultAddr
+		if flag.NArg() > 1 {
+			addr = flag.Arg(1)
 		}
+		return host(cfg, addr, *spectate)
+	case "serve":
+		serveFlags := flag.NewFlagSet("serve", flag.ContinueOnError)
+		addr := serveFlags.String("ssh", defaultSSHAddr, "address to accept SSH connections on")
+		switch err := serveFlags.Parse(flag.Args()[1:]); {
+		case errors.Is(err, flag.ErrHelp):
+			return 0
+		case err != nil:
+			return 2
+		}
+		return serve(cfg, *addr)
+	default:
+		flag.Usage()
+		return 2
+	}
 
-		// Update balloons
-		for i := range m.balloons {
-			if !m.balloons[i].popped {
-				// Move upward with slight horizontal wobble
-				m.balloons[i].y--
-				m.balloons[i].x += rand.Intn(3) - 1
-
-				// Keep within bounds
-				if m.balloons[i].x < m.minBalloonX {
-					m.balloons[i].x = m.minBalloonX
-				}
-				if m.balloons[i].x > m.maxBalloonX {
-					m.balloons[i].x = m.maxBalloonX
-				}
-
-				// Remove if it reaches the top
-				if m.balloons[i].y < 0 {
-					m.balloons[i].popped = true
-				}
-			}
+	model := game.New(cfg)
+
+	// A broken sprite pack falls back to the built-in art
+	if cfg.SpritePack != "" {
+		pack, err := spritepack.Open(cfg.SpritePack)
+		if err != nil {
+			fmt.Printf("Could not load sprite pack: %v\n", err)
+		} else {
+			model = model.WithSprites(pack)
 		}
+	}
 
-		// Check collisions
-		for i := range m.arrows {
-			if m.arrows[i].active {
-				for j := range m.balloons {
-					if !m.balloons[j].popped &&
-						m.arrows[i].x+4 >= m.balloons[j].x &&
-						m.arrows[i].x <= m.balloons[j].x+m.balloons[j].width &&
-						m.arrows[i].y >= m.balloons[j].y &&
-						m.arrows[i].y <= m.balloons[j].y+m.balloons[j].height {
-						m.balloons[j].popped = true
-						m.arrows[i].active = false
-						m.score++
-						// Replace balloon with explosion
-						m.balloons[j].symbol = []string{
-							"  \\|/  ",
-							"  /|\\  ",
-							"   *   ",
-						}
-						m.balloons[j].height = 3
-						m.balloons[j].width = 7
-					}
-				}
+	if *pluginPaths != "" {
+		var kinds []extension.Kind
+		for _, path := range strings.Split(*pluginPaths, ",") {
+			k, err := plugins.Open(path)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Could not load plugin: %v\n", err)
+				return 1
 			}
+			kinds = append(kinds, k...)
 		}
+		model = model.WithExtensions(kinds)
+	}
 
-		// Clean up inactive elements
-		m.arrows = filterActiveArrows(m.arrows)
-		m.balloons = filterActiveBalloons(m.balloons)
+	if *levelPath != "" {
+		l, err := level.Load(*levelPath)
+		if err == nil {
+			err = game.CheckLevel(l)
+		}
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Could not load level: %v\n", err)
+			return 1
+		}
+		model = model.WithLevel(l)
+	}
 
-		return m, tea.Batch(tick(), spawnBalloon())
+	// Headless runs skip the terminal and leave saved progress alone
+	if *headless {
+		stats := model.Start().Simulate(*ticks).Stats()
+		if err := json.NewEncoder(os.Stdout).Encode(stats); err != nil {
+			fmt.Fprintf(os.Stderr, "Could not write stats: %v\n", err)
+			return 1
+		}
+		return 0
 	}
 
-	return m, nil
-}
+	if configPath != "" {
+		model = model.WithConfigFile(configPath, configFile)
+	}
+	model = model.WithSound(soundPlayer(cfg))
+	if cfg.MusicVolume > 0 {
+		music, err := sound.NewMusic(cfg.MusicFile, float64(cfg.MusicVolume)/100)
+		if err != nil {
+			fmt.Printf("Could not play music: %v\n", err)
+		} else {
+			model = model.WithMusic(music)
+		}
+	}
 
-// View renders the game
-func (m Model) View() string {
-	// Create game board
-	board := make([][]string, m.height)
-	for i := range board {
-		board[i] = make([]string, m.width)
-		for j := range board[i] {
-			board[i][j] = " "
+	// Load high scores; a broken file shouldn't stop the game
+	if path, err := scores.DefaultPath(); err == nil {
+		table, err := scores.Load(path)
+		if err != nil {
+			// Keep scores in memory only so the broken file isn't overwritten
+			fmt.Printf("Could not load high scores: %v\n", err)
+		} else {
+			model = model.WithScores(table, path)
 		}
 	}
 
-	// Draw archer
-	archerStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("214"))
-	bowSymbol := "|)"
-	board[m.archer][0] = archerStyle.Render(bowSymbol)
+	// Ghosts of best runs load the same way as scores
+	if path, err := replay.DefaultPath(); err == nil {
+		ghosts, err := replay.Load(path)
+		if err != nil {
+			fmt.Printf("Could not load ghosts: %v\n", err)
+		} else {
+			model = model.WithGhosts(ghosts, path)
+		}
+	}
 
-	// Draw arrows
-	for _, arrow := range m.arrows {
-		if arrow.active && arrow.x < m.width {
-			board[arrow.y][arrow.x] = arrow.symbol
+	// Keep the daily challenge history alongside
+	if path, err := daily.DefaultPath(); err == nil {
+		history, err := daily.Load(path)
+		if err != nil {
+			fmt.Printf("Could not load daily history: %v\n", err)
+		} else {
+			model = model.WithDaily(history, path)
 		}
 	}
 
-	// Draw balloons
-	for _, balloon := range m.balloons {
-		if !balloon.popped {
-			balloonStyle := lipgloss.NewStyle().Foreground(balloon.color)
-			// Draw each line of the balloon
-			for i, line := range balloon.symbol {
-				if balloon.y+i >= 0 && balloon.y+i < m.height {
-					for j, char := range line {
-						if balloon.x+j < m.width {
-							board[balloon.y+i][balloon.x+j] = balloonStyle.Render(string(char))
-						}
-					}
-				}
-			}
+	// Load achievements the same way; on failure play without them
+	if path, err := achievements.DefaultPath(); err == nil {
+		engine, err := achievements.Load(path)
+		if err != nil {
+			fmt.Printf("Could not load achievements: %v\n", err)
+		} else {
+			model = model.WithAchievements(engine)
 		}
 	}
 
-	// Render board with border
-	var gameArea string
-	for i := range board {
-		row := ""
-		for j := range board[i] {
-			row += board[i][j]
+	// Lifetime XP too; without it nothing is locked
+	if path, err := profile.DefaultPath(); err == nil {
+		p, err := profile.Load(path)
+		if err != nil {
+			fmt.Printf("Could not load profile: %v\n", err)
+		} else {
+			model = model.WithProfile(p, path)
 		}
-		gameArea += row + "\n"
-	}
-
-	// Create border styles
-	borderStyle := lipgloss.NewStyle().
-		BorderStyle(lipgloss.RoundedBorder()).
-		BorderForeground(lipgloss.Color("63")). // Light blue border
-		Padding(0, 1).                          // Add some padding
-		Width(m.width + 2).                     // Account for padding
-		Align(lipgloss.Center)
-
-	// Create title style
-	titleStyle := lipgloss.NewStyle().
-		Foreground(lipgloss.Color("213")). // Pink color
-		Bold(true).
-		MarginBottom(1)
-
-	// Create score style
-	scoreStyle := lipgloss.NewStyle().
-		Foreground(lipgloss.Color("205")).
-		MarginTop(1)
-
-	// Create controls style
-	controlsStyle := lipgloss.NewStyle().
-		Foreground(lipgloss.Color("241")). // Subtle gray
-		MarginTop(1)
-
-	// Combine all elements
-	return lipgloss.JoinVertical(
-		lipgloss.Center,
-		titleStyle.Render("🎯 Balloon Archer 🎈"),
-		borderStyle.Render(gameArea),
-		scoreStyle.Render(fmt.Sprintf("Score: %d", m.score)),
-		controlsStyle.Render("Controls: ↑/↓ to move, SPACE to shoot, q to quit"),
-	)
-}
+	}
 
-type tickMsg time.Time
+	// A run quit part way through last time is offered from the menu
+	if path, err := savegame.DefaultPath(); err == nil {
+		r, err := savegame.Load(path)
+		if err == nil && r != nil {
+			err = game.CheckSave(r)
+		}
+		if err != nil {
+			fmt.Printf("Could not load saved game: %v\n", err)
+			r = nil
+		}
+		model = model.WithSavedRun(r, path)
+	}
 
-func tick() tea.Cmd {
-	return tea.Tick(time.Second/10, func(t time.Time) tea.Msg {
-		return tickMsg(t)
-	})
+	// The online leaderboard is opt-in
+	if cfg.LeaderboardURL != "" {
+		model = model.WithLeaderboard(leaderboard.New(cfg.LeaderboardURL))
+	}
+
+	// So is letting a stream's chat spawn balloons; play on without it if
+	// the chat can't be reached
+	if cfg.ChatChannel != "" {
+		client, err := chat.Dial(cfg.ChatServer, cfg.ChatChannel)
+		if err != nil {
+			fmt.Printf("Could not join chat: %v\n", err)
+		} else {
+			defer client.Close()
+			limit := chat.NewLimiter(cfg.ChatRate, time.Duration(cfg.ChatCooldown)*time.Second)
+			model = model.WithChat(client, limit)
+		}
+	}
+
+	return play(model, *spectate)
 }
 
-type spawnMsg Balloon
-
-func spawnBalloon() tea.Cmd {
-	return func() tea.Msg {
-		if rand.Float64() < 0.1 {
-			balloonArts := [][]string{
-				{
-					"  .-^^-.",
-					" /      \\",
-					"|        |",
-					" \\      /",
-					"  `----´",
-					"    ||   ",
-				},
-				{
-					"  .===.",
-					" (     )",
-					"|       |",
-					" (     )",
-					"  `---´",
-					"   ||  ",
-				},
-				{
-					"  _____",
-					" /     \\",
-					"|   ○   |",
-					" \\     /",
-					"  ‾‾‾‾‾",
-					"   ||   ",
-				},
-				{
-					"  .===.",
-					" /     \\",
-					"|   •   |",
-					" \\     /",
-					"  `---´",
-					"   ||   ",
-				},
-			}
+// defaultAddr is where networked matches are hosted unless told otherwise
+const defaultAddr = ":7777"
+
+// defaultSSHAddr is where the SSH server listens unless told otherwise
+const defaultSSHAddr = ":2222"
+
+// setBackground tells lipgloss which shade of each themed color to use.
+// Asking the terminal has to happen before Bubble Tea starts reading input,
+// or the terminal's answer would arrive as key presses.
+func setBackground(background string) {
+	switch background {
+	case config.BackgroundDark:
+		lipgloss.SetHasDarkBackground(true)
+	case config.BackgroundLight:
+		lipgloss.SetHasDarkBackground(false)
+	default:
+		lipgloss.HasDarkBackground()
+	}
+}
 
-			balloonColors := []lipgloss.Color{
-				"213", // Pink
-				"204", // Red
-				"39",  // Blue
-				"48",  // Green
-			}
+// monochrome reports whether to draw without color: the player asked for
+// it with NO_COLOR (https://no-color.org), or the terminal can't show any
+func monochrome() bool {
+	return os.Getenv("NO_COLOR") != "" || lipgloss.ColorProfile() == termenv.Ascii
+}
+
+// soundPlayer makes the player cfg asks for. Without an audio device it
+// falls back to the bell.
+func soundPlayer(cfg config.Config) sound.Player {
+	bell := func() sound.Player {
+		var effects []sound.Effect
+		for _, name := range cfg.BellOn {
+			e, _ := sound.Lookup(name)
+			effects = append(effects, e)
+		}
+		// The bell goes to stderr so it never lands inside a frame
+		return sound.NewBell(os.Stderr, effects...)
+	}
+	switch cfg.Sound {
+	case config.SoundBell:
+		return bell()
+	case config.SoundAudio:
+		player, err := sound.NewAudio()
+		if err != nil {
+			fmt.Printf("Could not play audio, ringing the bell instead: %v\n", err)
+			return bell()
+		}
+		return player
+	}
+	return nil
+}
 
-			symbolIndex := rand.Intn(len(balloonArts))
-			selectedBalloon := balloonArts[symbolIndex]
-
-			// Calculate balloon dimensions
-			width := len(selectedBalloon[0])
-			height := len(selectedBalloon)
-
-			screenWidth := 80
-			minX := screenWidth / 2
-			maxX := screenWidth - width - 2
-			spawnX := minX + rand.Intn(maxX-minX)
-
-			return spawnMsg(Balloon{
-				x:      spawnX,
-				y:      19,
-				popped: false,
-				symbol: selectedBalloon,
-				color:  balloonColors[symbolIndex],
-				width:  width,
-				height: height,
-			})
+// asciiOnly guesses whether the terminal can only show ASCII: the Linux
+// console and old terminals lack the fonts, and a non-UTF-8 locale can't
+// encode the symbols at all
+func asciiOnly() bool {
+	switch os.Getenv("TERM") {
+	case "linux", "vt100", "vt220", "dumb":
+		return true
+	}
+	for _, name := range []string{"LC_ALL", "LC_CTYPE", "LANG"} {
+		if locale := strings.ToLower(os.Getenv(name)); locale != "" {
+			return !strings.Contains(locale, "utf-8") && !strings.Contains(locale, "utf8")
 		}
-		return nil
 	}
+	return false
 }
 
-func filterActiveArrows(arrows []Arrow) []Arrow {
-	active := make([]Arrow, 0)
-	for _, arrow := range arrows {
-		if arrow.active {
-			active = append(active, arrow)
+// play runs model in the terminal until it quits, streaming its frames to
+// watchers on spectate unless that's empty, and returns the exit code. The
+// game takes over the alt screen, so the shell's scrollback is left as it
+// was.
+func play(model tea.Model, spectate string) (code int) {
+	if spectate != "" {
+		cast, err := netplay.Broadcast(spectate)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Could not open %s for spectators: %v\n", spectate, err)
+			return 1
 		}
+		defer cast.Close()
+		model = netplay.Stream(model, cast)
 	}
-	return active
+
+	// Bubble Tea's own panic handler is turned off so this one can put the
+	// terminal back before the stack trace, where the player can read it.
+	// Its signal handler is too, so a signal can save the run first. Focus
+	// is reported so the game can pause itself when the window loses it.
+	p := tea.NewProgram(model, tea.WithAltScreen(), tea.WithoutCatchPanics(), tea.WithoutSignalHandler(),
+		tea.WithReportFocus())
+	defer quitOnSignal(p)()
+	defer func() {
+		if r := recover(); r != nil {
+			p.Kill()
+			fmt.Fprintf(os.Stderr, "panic: %v\n\n%s", r, debug.Stack())
+			code = 1
+		}
+	}()
+	if _, err := p.Run(); err != nil {
+		fmt.Fprintf(os.Stderr, "Error running program: %v\n", err)
+		return 1
+	}
+	return 0
 }
 
-func filterActiveBalloons(balloons []Balloon) []Balloon {
-	active := make([]Balloon, 0)
-	for _, balloon := range balloons {
-		if !balloon.popped {
-			active = append(active, balloon)
+// hangupGrace is how long the game gets to save and quit after a signal
+// before it's made to
+const hangupGrace = 2 * time.Second
+
+// quitOnSignal has p save what it can and quit when the process is told to
+// stop or its terminal hangs up, so closing the window neither loses the
+// run nor leaves the terminal in raw mode. The returned func stops
+// listening.
+func quitOnSignal(p *tea.Program) func() {
+	sig := make(chan os.Signal, 1)
+	signal.Notify(sig, syscall.SIGINT, syscall.SIGTERM, syscall.SIGHUP)
+	done := make(chan struct{})
+	go func() {
+		select {
+		case <-sig:
+			p.Send(game.HangupMsg{})
+			// Screens that don't save anything may not know the message
+			time.AfterFunc(hangupGrace, p.Quit)
+		case <-done:
 		}
+	}()
+	return func() {
+		signal.Stop(sig)
+		close(done)
 	}
-	return active
 }
 
-func main() {
-	rand.Seed(time.Now().UnixNano())
+// host waits for one player to join on addr, then starts the match
+func host(cfg config.Config, addr, spectate string) int {
+	l, err := netplay.Listen(addr)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Could not host: %v\n", err)
+		return 1
+	}
+	fmt.Printf("Waiting for a player to join on %s…\n", l.Addr())
+	conn, err := netplay.Accept(l)
+	l.Close()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Could not accept player: %v\n", err)
+		return 1
+	}
+	return play(game.NewHost(game.New(cfg), conn), spectate)
+}
+
+// serve hosts a game for everyone who connects over SSH to addr
+func serve(cfg config.Config, addr string) int {
+	if err := serveSSH(cfg, addr); err != nil {
+		fmt.Fprintf(os.Stderr, "Could not serve: %v\n", err)
+		return 1
+	}
+	return 0
+}
+
+func serveSSH(cfg config.Config, addr string) error {
+	keyPath, err := sshserve.HostKeyPath()
+	if err != nil {
+		return err
+	}
+	path, err := leaderboard.LocalPath()
+	if err != nil {
+		return err
+	}
+	board, err := leaderboard.LoadLocal(path)
+	if err != nil {
+		return err
+	}
+	fmt.Printf("Serving over SSH on %s\n", addr)
+	return sshserve.Serve(addr, keyPath, cfg, board)
+}
 
-	p := tea.NewProgram(initialModel())
-	if err := p.Start(); err != nil {
-		fmt.Printf("Error running program: %v", err)
-		return
+// join connects to the game at addr, as a player or a read-only watcher
+func join(addr string, asPlayer bool) int {
+	conn, err := netplay.Dial(addr)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Could not connect to %s: %v\n", addr, err)
+		return 1
 	}
+	return play(netplay.NewViewer(conn, asPlayer), "")
 }
@@ -0,0 +1,95 @@
+package main
+
+import (
+	"fmt"
+	"net"
+	"net/http"
+	_ "net/http/pprof" // serves the profiles under /debug/pprof/ when --pprof is set
+	"os"
+	"runtime"
+	"runtime/pprof"
+	"runtime/trace"
+)
+
+// profiling is what the profiling flags ask for; empty fields are off
+type profiling struct {
+	cpu, mem, trace string // files to write each profile to
+	listen          string // address to serve live profiles on
+}
+
+// start begins profiling. The returned func stops it and writes what was
+// collected, so call it on the way out.
+func (p profiling) start() (func(), error) {
+	var stops []func()
+	stop := func() {
+		for i := len(stops) - 1; i >= 0; i-- {
+			stops[i]()
+		}
+	}
+
+	// The address is taken first, so one that's bad or in use is reported
+	// before anything else starts, let alone the game
+	if p.listen != "" {
+		l, err := net.Listen("tcp", p.listen)
+		if err != nil {
+			return nil, err
+		}
+		go http.Serve(l, nil)
+		stops = append(stops, func() { l.Close() })
+	}
+
+	if p.cpu != "" {
+		f, err := os.Create(p.cpu)
+		if err != nil {
+			return nil, err
+		}
+		if err := pprof.StartCPUProfile(f); err != nil {
+			f.Close()
+			return nil, err
+		}
+		stops = append(stops, func() {
+			pprof.StopCPUProfile()
+			f.Close()
+		})
+	}
+
+	if p.trace != "" {
+		f, err := os.Create(p.trace)
+		if err != nil {
+			stop()
+			return nil, err
+		}
+		if err := trace.Start(f); err != nil {
+			f.Close()
+			stop()
+			return nil, err
+		}
+		stops = append(stops, func() {
+			trace.Stop()
+			f.Close()
+		})
+	}
+
+	if p.mem != "" {
+		path := p.mem
+		stops = append(stops, func() {
+			if err := writeHeapProfile(path); err != nil {
+				fmt.Fprintf(os.Stderr, "Could not write memory profile: %v\n", err)
+			}
+		})
+	}
+
+	return stop, nil
+}
+
+// writeHeapProfile writes the live heap to path, after a collection so it
+// only holds what's still in use
+func writeHeapProfile(path string) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	runtime.GC()
+	return pprof.WriteHeapProfile(f)
+}